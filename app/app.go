@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs"
+	authhandlers "zatrano/handlers/auth"
+	dashboardhandlers "zatrano/handlers/dashboard"
+	healthhandlers "zatrano/handlers/health"
+	localehandlers "zatrano/handlers/locale"
+	"zatrano/middlewares"
+	"zatrano/pkg/health"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/lifecycle"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/templatehelpers"
+	"zatrano/repositories"
+	"zatrano/routes"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/gofiber/template/html/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Repositories struct {
+	User     repositories.IUserRepository
+	AuditLog repositories.IAuditLogRepository
+}
+
+type Services struct {
+	Auth     services.IAuthService
+	User     services.IUserService
+	AuditLog services.IAuditLogService
+}
+
+type App struct {
+	Config       *configs.Config
+	DB           *gorm.DB
+	TxManager    repositories.ITxManager
+	SessionStore *session.Store
+	Health       *health.State
+	Repositories Repositories
+	Services     Services
+	Server       *fiber.App
+
+	healthHandler     *healthhandlers.HealthHandler
+	userImportHandler *dashboardhandlers.UserImportHandler
+}
+
+func New(cfg *configs.Config) (*App, error) {
+	logs.InitLogger(cfg.App.Env, cfg.Log.Level)
+
+	if err := i18n.Init(cfg.Locale.Dir, cfg.Locale.Default, cfg.Locale.Timezone); err != nil {
+		return nil, err
+	}
+
+	db, err := configs.OpenDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithDB(cfg, db), nil
+}
+
+func NewWithDB(cfg *configs.Config, db *gorm.DB) *App {
+	return newApp(cfg, db, repositories.NewTxManager(db, cfg.Database.TxMaxRetries), Repositories{
+		User:     repositories.NewUserRepository(db),
+		AuditLog: repositories.NewAuditLogRepository(db),
+	})
+}
+
+// newApp servisleri, handler'ları ve rotaları verilen repository'lerin
+// üzerine kurar. Testler veritabanı yerine sahte repository'lerle kullanır.
+func newApp(cfg *configs.Config, db *gorm.DB, txManager repositories.ITxManager, repos Repositories) *App {
+	a := &App{
+		Config:       cfg,
+		DB:           db,
+		TxManager:    txManager,
+		SessionStore: configs.NewSessionStore(cfg),
+		Health:       health.NewState(),
+		Repositories: repos,
+	}
+
+	a.Services = Services{
+		Auth:     services.NewAuthService(a.Repositories.User),
+		User:     services.NewUserService(a.Repositories.User, a.TxManager),
+		AuditLog: services.NewAuditLogService(a.Repositories.AuditLog),
+	}
+
+	a.healthHandler = healthhandlers.NewHealthHandler(db, a.SessionStore, a.Health)
+	a.userImportHandler = dashboardhandlers.NewUserImportHandler(a.Services.User)
+
+	a.Server = newServer()
+	routes.SetupRoutes(a.Server, routes.Dependencies{
+		SessionStore:         a.SessionStore,
+		Metrics:              cfg.Metrics,
+		QueryTimeout:         time.Duration(cfg.Database.QueryTimeoutMs) * time.Millisecond,
+		Middleware:           middlewares.New(a.Services.Auth),
+		AuthHandler:          authhandlers.NewAuthHandler(a.Services.Auth, cfg.IsProduction()),
+		DashboardHomeHandler: dashboardhandlers.NewDashboardHomeHandler(a.Services.User),
+		UserHandler:          dashboardhandlers.NewUserHandler(a.Services.User),
+		AuditLogHandler:      dashboardhandlers.NewAuditLogHandler(a.Services.AuditLog),
+		UserImportHandler:    a.userImportHandler,
+		HealthHandler:        a.healthHandler,
+		LocaleHandler:        localehandlers.NewLocaleHandler(cfg.IsProduction()),
+	})
+
+	return a
+}
+
+func (a *App) Run(ctx context.Context) error {
+	// Trafik boşaltma beklemesi kapatma süresine eklenir; aksi halde bekleme
+	// süreyi tüketir ve HTTP sunucusu bekleyen istekleri tamamlayamadan kesilir.
+	drain := time.Duration(a.Config.Shutdown.DrainSeconds) * time.Second
+	shutdownTimeout := time.Duration(a.Config.Shutdown.TimeoutSeconds) * time.Second
+	manager := lifecycle.New(drain + shutdownTimeout)
+	a.registerComponents(manager)
+	return manager.Run(ctx)
+}
+
+func newServer() *fiber.App {
+	engine := html.New("./views", ".html")
+	engine.AddFuncMap(templatehelpers.TemplateHelpers())
+
+	server := fiber.New(fiber.Config{
+		Views:       engine,
+		ReadTimeout: 30 * time.Second,
+		IdleTimeout: 60 * time.Second,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			code := fiber.StatusInternalServerError
+			message := "Internal Server Error"
+
+			if e, ok := err.(*fiber.Error); ok {
+				code = e.Code
+				message = e.Message
+			}
+
+			logs.Log.Error("Fiber request error",
+				zap.Error(err),
+				zap.Int("status_code", code),
+				zap.String("method", c.Method()),
+				zap.String("path", c.Path()),
+				zap.String("ip", c.IP()),
+			)
+
+			return c.Status(code).JSON(fiber.Map{"error": message})
+		},
+	})
+
+	server.Static("/", "./public")
+	return server
+}
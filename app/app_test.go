@@ -0,0 +1,623 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/pkg/customerrors"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+// fakeUserRepository kullanıcıları bellekte tutar. Yalnızca kullanıcı
+// sayfalarının ve girişin kullandığı metotlar uygulanmıştır; diğerleri
+// gömülü nil arayüz yüzünden panic eder ve testte hemen fark edilir.
+type fakeUserRepository struct {
+	repositories.IUserRepository
+
+	mu     sync.Mutex
+	users  map[uint]*models.User
+	nextID uint
+}
+
+func newFakeUserRepository() *fakeUserRepository {
+	return &fakeUserRepository{users: make(map[uint]*models.User), nextID: 1}
+}
+
+func (r *fakeUserRepository) active(id uint) (*models.User, bool) {
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, false
+	}
+	return user, true
+}
+
+func (r *fakeUserRepository) Create(_ context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.users {
+		if existing.Account == user.Account && !existing.DeletedAt.Valid {
+			return customerrors.ErrImportAccountExists
+		}
+	}
+	user.ID = r.nextID
+	user.Version = 1
+	user.CreatedAt = time.Now()
+	r.nextID++
+	// Fiber form değerleri isteğin tamponunu paylaşır; veritabanı gibi
+	// kopyalanmazsa sonraki istek saklanan metni değiştirir.
+	stored := *user
+	stored.Name, stored.Account = strings.Clone(user.Name), strings.Clone(user.Account)
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *fakeUserRepository) GetByID(_ context.Context, id uint) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.active(id)
+	if !ok {
+		return nil, customerrors.ErrRepoRecordNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepository) FindUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *fakeUserRepository) FindUserByAccount(_ context.Context, account string) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if user.Account == account && !user.DeletedAt.Valid {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, customerrors.ErrRepoRecordNotFound
+}
+
+func (r *fakeUserRepository) GetCount(_ context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for id := range r.users {
+		if _, ok := r.active(id); ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeUserRepository) List(_ context.Context, params queryparams.ListParams) ([]models.User, queryparams.PaginationMeta, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var users []models.User
+	for id := range r.users {
+		if user, ok := r.active(id); ok {
+			users = append(users, *user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID > users[j].ID })
+	meta := queryparams.PaginationMeta{
+		CurrentPage: params.Page,
+		PerPage:     params.PerPage,
+		TotalItems:  int64(len(users)),
+		TotalPages:  queryparams.CalculateTotalPages(int64(len(users)), params.PerPage),
+		CountMode:   queryparams.CountExact,
+	}
+	return users, meta, nil
+}
+
+func (r *fakeUserRepository) ListVersions(context.Context, uint) ([]models.RecordVersion, error) {
+	return nil, nil
+}
+
+func (r *fakeUserRepository) UpdateUserFields(_ context.Context, id uint, version uint, data map[string]interface{}, updatedBy uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if version == 0 {
+		return customerrors.ErrVersionRequired
+	}
+	user, ok := r.active(id)
+	if !ok {
+		return customerrors.ErrRepoRecordNotFound
+	}
+	if user.Version != version {
+		return customerrors.ErrVersionConflict
+	}
+	r.apply(user, data, updatedBy)
+	return nil
+}
+
+func (r *fakeUserRepository) UpdateUserFieldsUnversioned(_ context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.active(id)
+	if !ok {
+		return customerrors.ErrRepoRecordNotFound
+	}
+	r.apply(user, data, updatedBy)
+	return nil
+}
+
+func (r *fakeUserRepository) apply(user *models.User, data map[string]interface{}, updatedBy uint) {
+	for key, value := range data {
+		switch key {
+		case "name":
+			user.Name = strings.Clone(value.(string))
+		case "account":
+			user.Account = strings.Clone(value.(string))
+		case "password":
+			user.Password = value.(string)
+		case "status":
+			user.Status = value.(bool)
+		case "type":
+			user.Type = value.(models.UserType)
+		case "locale":
+			user.Locale = value.(string)
+		case "timezone":
+			user.Timezone = value.(string)
+		}
+	}
+	user.UpdatedBy = updatedBy
+	user.Version++
+}
+
+func (r *fakeUserRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.active(id)
+	if !ok {
+		return customerrors.ErrRepoRecordNotFound
+	}
+	user.DeletedAt.Time, user.DeletedAt.Valid = time.Now(), true
+	return nil
+}
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error, _ ...*sql.TxOptions) error {
+	return fn(ctx)
+}
+
+var csrfTokenPattern = regexp.MustCompile(`name="csrf_token"[^>]*value="([^"]+)"|name="csrf_token" content="([^"]+)"`)
+
+// testClient isteklerde çerezleri ve son görülen CSRF token'ını taşır.
+type testClient struct {
+	t       *testing.T
+	app     *App
+	cookies map[string]string
+	csrf    string
+	// header her isteğe eklenir; ör. XHR isteği için X-Requested-With.
+	header http.Header
+}
+
+func (c *testClient) do(method, path string, form url.Values) (*http.Response, string) {
+	c.t.Helper()
+	var body io.Reader
+	if form != nil {
+		if c.csrf != "" {
+			form.Set("csrf_token", c.csrf)
+		}
+		body = strings.NewReader(form.Encode())
+	}
+	req := httptest.NewRequest(method, path, body)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for name, value := range c.cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+
+	resp, err := c.app.Server.Test(req, -1)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.MaxAge < 0 || cookie.Value == "" {
+			delete(c.cookies, cookie.Name)
+		} else {
+			c.cookies[cookie.Name] = cookie.Value
+		}
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	html := string(data)
+	if match := csrfTokenPattern.FindStringSubmatch(html); match != nil {
+		c.csrf = match[1] + match[2]
+	}
+	return resp, html
+}
+
+func (c *testClient) get(path string) (*http.Response, string) {
+	return c.do(http.MethodGet, path, nil)
+}
+
+func (c *testClient) post(path string, form url.Values) (*http.Response, string) {
+	return c.do(http.MethodPost, path, form)
+}
+
+// expect yanıt kodunu ve yönlendirmeyse hedefini kontrol eder.
+func (c *testClient) expect(resp *http.Response, status int, location string) {
+	c.t.Helper()
+	if resp.StatusCode != status {
+		c.t.Fatalf("durum = %d, want %d", resp.StatusCode, status)
+	}
+	if got := resp.Header.Get("Location"); got != location {
+		c.t.Fatalf("Location = %q, want %q", got, location)
+	}
+}
+
+func (c *testClient) login(account, password string) *http.Response {
+	c.t.Helper()
+	c.get("/auth/login")
+	resp, _ := c.post("/auth/login", url.Values{"account": {account}, "password": {password}})
+	return resp
+}
+
+func newTestApp(t *testing.T) (*App, *fakeUserRepository) {
+	t.Helper()
+	t.Chdir("..")
+	if logs.Log == nil {
+		logs.Log = zap.NewNop()
+		logs.SLog = logs.Log.Sugar()
+	}
+	cfg := configs.Default()
+	if err := i18n.Init(cfg.Locale.Dir, "tr", cfg.Locale.Timezone); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newFakeUserRepository()
+	app := newApp(cfg, nil, fakeTxManager{}, Repositories{User: repo})
+
+	admin := &models.User{Name: "Yönetici", Account: "admin", Password: "admin-pass", Status: true, Type: models.Dashboard}
+	if err := app.Services.User.CreateUser(context.Background(), admin); err != nil {
+		t.Fatal(err)
+	}
+	return app, repo
+}
+
+func newTestClient(t *testing.T, app *App) *testClient {
+	return &testClient{t: t, app: app, cookies: make(map[string]string)}
+}
+
+func TestLogin(t *testing.T) {
+	app, repo := newTestApp(t)
+	passive := &models.User{Name: "Pasif", Account: "passive", Password: "passive-pass", Status: false, Type: models.Dashboard}
+	if err := app.Services.User.CreateUser(context.Background(), passive); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, account, password string
+		location                string
+		message                 string
+	}{
+		{"doğru şifre", "admin", "admin-pass", "/dashboard/home", "Başarıyla giriş yapıldı."},
+		{"yanlış şifre", "admin", "wrong", "/auth/login", "Kullanıcı adı veya şifre hatalı"},
+		{"olmayan hesap", "ghost", "admin-pass", "/auth/login", "Kullanıcı adı veya şifre hatalı"},
+		{"pasif kullanıcı", "passive", "passive-pass", "/auth/login", "Hesabınız aktif değil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, app)
+			resp := client.login(tt.account, tt.password)
+			if resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusSeeOther {
+				t.Fatalf("durum = %d, yönlendirme bekleniyordu", resp.StatusCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.location {
+				t.Fatalf("Location = %q, want %q", got, tt.location)
+			}
+			_, html := client.get(tt.location)
+			if !strings.Contains(html, tt.message) {
+				t.Errorf("%s sayfasında %q mesajı yok", tt.location, tt.message)
+			}
+		})
+	}
+
+	t.Run("giriş yapmadan panel açılmaz", func(t *testing.T) {
+		resp, _ := newTestClient(t, app).get("/dashboard/users")
+		if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != "/auth/login" {
+			t.Errorf("durum = %d, Location = %q", resp.StatusCode, resp.Header.Get("Location"))
+		}
+	})
+
+	if len(repo.users) != 2 {
+		t.Errorf("girişler kullanıcı oluşturmamalı: %d kullanıcı", len(repo.users))
+	}
+}
+
+func TestDashboardUserPages(t *testing.T) {
+	app, repo := newTestApp(t)
+	client := newTestClient(t, app)
+	client.expect(client.login("admin", "admin-pass"), http.StatusFound, "/dashboard/home")
+
+	t.Run("liste", func(t *testing.T) {
+		resp, html := client.get("/dashboard/users")
+		client.expect(resp, http.StatusOK, "")
+		if !strings.Contains(html, "Yönetici") || !strings.Contains(html, "admin") {
+			t.Error("listede yönetici kullanıcısı yok")
+		}
+	})
+
+	var created *models.User
+	t.Run("oluşturma", func(t *testing.T) {
+		resp, _ := client.get("/dashboard/users/create")
+		client.expect(resp, http.StatusOK, "")
+
+		resp, _ = client.post("/dashboard/users/create", url.Values{
+			"name": {"Çağla Öztürk"}, "account": {"cagla"}, "password": {"cagla-pass"},
+			"status": {"true"}, "type": {"panel"},
+		})
+		client.expect(resp, http.StatusFound, "/dashboard/users")
+
+		var err error
+		if created, err = repo.FindUserByAccount(context.Background(), "cagla"); err != nil {
+			t.Fatalf("kullanıcı oluşturulmadı: %v", err)
+		}
+		if created.Password == "cagla-pass" || created.CheckPassword("cagla-pass") != nil {
+			t.Errorf("şifre hashlenmemiş: %+v", created)
+		}
+		_, html := client.get("/dashboard/users")
+		if !strings.Contains(html, "Çağla Öztürk") || !strings.Contains(html, "Kullanıcı başarıyla oluşturuldu.") {
+			t.Error("yeni kullanıcı veya başarı mesajı listede yok")
+		}
+
+		resp, html = client.post("/dashboard/users/create", url.Values{"name": {"Eksik"}, "type": {"panel"}})
+		client.expect(resp, http.StatusBadRequest, "")
+		if !strings.Contains(html, "alanları zorunludur") {
+			t.Error("zorunlu alan hatası gösterilmedi")
+		}
+	})
+	if created == nil {
+		t.FailNow()
+	}
+	editPath := "/dashboard/users/update/" + strconv.Itoa(int(created.ID))
+
+	t.Run("düzenleme", func(t *testing.T) {
+		resp, html := client.get(editPath)
+		client.expect(resp, http.StatusOK, "")
+		if !strings.Contains(html, `name="version" value="1"`) {
+			t.Fatal("formda sürüm alanı yok")
+		}
+
+		form := func(name, version string) url.Values {
+			values := url.Values{"name": {name}, "account": {"cagla"}, "status": {"true"}, "type": {"dashboard"}}
+			if version != "" {
+				values.Set("version", version)
+			}
+			return values
+		}
+
+		resp, _ = client.post(editPath, form("Çağla Yılmaz", "1"))
+		client.expect(resp, http.StatusFound, "/dashboard/users")
+		user, _ := repo.GetByID(context.Background(), created.ID)
+		if user.Name != "Çağla Yılmaz" || user.Type != models.Dashboard || user.Version != 2 {
+			t.Errorf("güncelleme uygulanmadı: %+v", user)
+		}
+
+		// Form 1. sürümde açılmış: arada ad ve tip değişti, bu formda ise
+		// yalnızca durum değiştiriliyor.
+		stale := form("Eski Form", "1")
+		stale.Set("type", "panel")
+		stale.Set("status", "false")
+		stale.Set("base_name", "Çağla Öztürk")
+		stale.Set("base_account", "cagla")
+		stale.Set("base_type", "panel")
+		stale.Set("base_status", "true")
+		resp, html = client.post(editPath, stale)
+		client.expect(resp, http.StatusConflict, "")
+		if !strings.Contains(html, "başka biri tarafından güncellendi") {
+			t.Error("çakışma mesajı gösterilmedi")
+		}
+		for label, want := range map[string]bool{"Ad Soyad": true, "Kullanıcı Tipi": true, "Durum": false, "Hesap Adı": false} {
+			if got := strings.Contains(html, "<td>"+label+"</td>"); got != want {
+				t.Errorf("çakışma tablosunda %q = %v, want %v", label, got, want)
+			}
+		}
+		if !strings.Contains(html, `name="base_name" value="Çağla Yılmaz"`) || !strings.Contains(html, `name="version" value="2"`) {
+			t.Error("çakışma sonrası form güncel sürüme çekilmedi")
+		}
+
+		resp, html = client.post(editPath, form("Sürümsüz", ""))
+		client.expect(resp, http.StatusBadRequest, "")
+		if !strings.Contains(html, "sürüm bilgisi yok") {
+			t.Error("eksik sürüm hatası gösterilmedi")
+		}
+
+		if user, _ := repo.GetByID(context.Background(), created.ID); user.Name != "Çağla Yılmaz" {
+			t.Errorf("reddedilen form kaydı değiştirdi: %q", user.Name)
+		}
+
+		resp, _ = client.post(editPath+"/revert/1", url.Values{})
+		client.expect(resp, http.StatusSeeOther, editPath)
+	})
+
+	t.Run("paylaşılan repository ile giriş", func(t *testing.T) {
+		other := newTestClient(t, app)
+		other.expect(other.login("cagla", "cagla-pass"), http.StatusFound, "/dashboard/home")
+	})
+
+	t.Run("silme", func(t *testing.T) {
+		resp, _ := client.post("/dashboard/users/delete/"+strconv.Itoa(int(created.ID)), url.Values{})
+		client.expect(resp, http.StatusFound, "/dashboard/users")
+		if _, err := repo.GetByID(context.Background(), created.ID); err == nil {
+			t.Error("kullanıcı silinmedi")
+		}
+		_, html := client.get("/dashboard/users")
+		if strings.Contains(html, "Çağla Yılmaz") || !strings.Contains(html, "Kullanıcı silinenler listesine taşındı.") {
+			t.Error("silinen kullanıcı listede veya başarı mesajı yok")
+		}
+
+		resp, _ = client.post("/dashboard/users/delete/"+strconv.Itoa(int(created.ID)), url.Values{})
+		client.expect(resp, http.StatusSeeOther, "/dashboard/users")
+
+		other := newTestClient(t, app)
+		other.expect(other.login("cagla", "cagla-pass"), http.StatusSeeOther, "/auth/login")
+	})
+}
+
+// TestFlashMessages handler'ların flash mesajlarını, eski form değerlerini
+// ve alan hatalarını yönlendirilen sayfaya bir kez taşıdığını doğrular.
+func TestFlashMessages(t *testing.T) {
+	app, repo := newTestApp(t)
+
+	t.Run("eksik giriş alanları", func(t *testing.T) {
+		client := newTestClient(t, app)
+		client.get("/auth/login")
+		resp, _ := client.post("/auth/login", url.Values{"account": {"ayse"}, "password": {""}})
+		client.expect(resp, http.StatusSeeOther, "/auth/login")
+
+		_, html := client.get("/auth/login")
+		if !strings.Contains(html, "Lütfen hesap adı ve şifre alanlarını doldurun.") {
+			t.Error("hata mesajı gösterilmedi")
+		}
+		if !strings.Contains(html, `value="ayse"`) {
+			t.Error("hesap adı formda korunmadı")
+		}
+		_, html = client.get("/auth/login")
+		if strings.Contains(html, "Lütfen hesap adı") || strings.Contains(html, `value="ayse"`) {
+			t.Error("flash verisi ikinci sayfada tekrar gösterildi")
+		}
+	})
+
+	t.Run("yanlış şifrede şifre saklanmaz", func(t *testing.T) {
+		client := newTestClient(t, app)
+		client.expect(client.login("admin", "secret-guess"), http.StatusSeeOther, "/auth/login")
+		_, html := client.get("/auth/login")
+		if !strings.Contains(html, `value="admin"`) || strings.Contains(html, "secret-guess") {
+			t.Error("hesap adı korunmadı veya şifre sayfaya yazıldı")
+		}
+	})
+
+	client := newTestClient(t, app)
+	client.expect(client.login("admin", "admin-pass"), http.StatusFound, "/dashboard/home")
+
+	t.Run("alan hatası", func(t *testing.T) {
+		client.get("/auth/profile")
+		resp, _ := client.post("/auth/profile/update-password", url.Values{
+			"current_password": {"admin-pass"}, "new_password": {"new-pass-1"}, "confirm_password": {"new-pass-2"},
+		})
+		client.expect(resp, http.StatusSeeOther, "/auth/profile")
+
+		_, html := client.get("/auth/profile")
+		if !strings.Contains(html, `<div class="invalid-feedback">Yeni şifreler uyuşmuyor.</div>`) {
+			t.Error("confirm_password alan hatası gösterilmedi")
+		}
+		if strings.Contains(html, "new-pass-1") {
+			t.Error("şifre alanı sayfaya yazıldı")
+		}
+		if _, html = client.get("/auth/profile"); strings.Contains(html, "Yeni şifreler uyuşmuyor.") {
+			t.Error("alan hatası ikinci sayfada tekrar gösterildi")
+		}
+	})
+
+	t.Run("XHR yönlendirmesi", func(t *testing.T) {
+		user := &models.User{Name: "Silinecek", Account: "xhr", Password: "xhr-pass", Status: true, Type: models.Panel}
+		if err := app.Services.User.CreateUser(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+		client.get("/dashboard/users")
+		client.header = http.Header{"X-Requested-With": {"XMLHttpRequest"}}
+		resp, body := client.post("/dashboard/users/delete/"+strconv.Itoa(int(user.ID)), url.Values{})
+		client.header = nil
+
+		client.expect(resp, http.StatusOK, "")
+		want := `{"redirect":"/dashboard/users","messages":[{"level":"success","text":"Kullanıcı silinenler listesine taşındı.","dismissible":false}]}`
+		if body != want {
+			t.Errorf("XHR yanıtı = %s, want %s", body, want)
+		}
+		if _, err := repo.GetByID(context.Background(), user.ID); err == nil {
+			t.Error("kullanıcı silinmedi")
+		}
+		if _, html := client.get("/dashboard/users"); strings.Contains(html, "silinenler listesine taşındı") {
+			t.Error("XHR yanıtındaki mesaj sonraki sayfada tekrar gösterildi")
+		}
+	})
+}
+
+func TestLocaleSwitch(t *testing.T) {
+	app, _ := newTestApp(t)
+
+	t.Run("GET ile dil değişmez", func(t *testing.T) {
+		client := newTestClient(t, app)
+		resp, _ := client.get("/locale/en")
+		if resp.StatusCode == http.StatusSeeOther || client.cookies[i18n.CookieName] != "" {
+			t.Errorf("GET dil cookie'si yazdı: durum = %d, cookie = %q", resp.StatusCode, client.cookies[i18n.CookieName])
+		}
+	})
+
+	t.Run("CSRF token'ı olmadan dil değişmez", func(t *testing.T) {
+		client := newTestClient(t, app)
+		resp, _ := client.post("/locale/en", url.Values{})
+		client.expect(resp, http.StatusSeeOther, "/auth/login")
+		if client.cookies[i18n.CookieName] != "" {
+			t.Error("CSRF doğrulaması başarısız olan istek dil cookie'si yazdı")
+		}
+	})
+
+	t.Run("form ile dil değişir", func(t *testing.T) {
+		client := newTestClient(t, app)
+		if _, html := client.get("/auth/login"); !strings.Contains(html, `action="/locale/en"`) {
+			t.Fatal("giriş sayfasında dil formu yok")
+		}
+		client.header = http.Header{"Referer": {"http://example.com/auth/login"}}
+		resp, _ := client.post("/locale/en", url.Values{})
+		client.header = nil
+
+		client.expect(resp, http.StatusSeeOther, "/auth/login")
+		if got := client.cookies[i18n.CookieName]; got != "en" {
+			t.Fatalf("dil cookie'si = %q, en bekleniyordu", got)
+		}
+		if resp, _ := client.get("/auth/login"); resp.Header.Get("Content-Language") != "en" {
+			t.Errorf("Content-Language = %q, en bekleniyordu", resp.Header.Get("Content-Language"))
+		}
+	})
+
+	t.Run("girişte kayıtlı dil seçilen dilden önceliklidir", func(t *testing.T) {
+		saved := &models.User{Name: "Türkçe", Account: "saved-tr", Password: "saved-pass", Status: true, Type: models.Dashboard, Locale: "tr"}
+		if err := app.Services.User.CreateUser(context.Background(), saved); err != nil {
+			t.Fatal(err)
+		}
+		tests := []struct {
+			account, password, lang string
+		}{
+			{"saved-tr", "saved-pass", "tr"},
+			{"admin", "admin-pass", "en"},
+		}
+		for _, tt := range tests {
+			client := newTestClient(t, app)
+			client.get("/auth/login")
+			client.post("/locale/en", url.Values{})
+			client.expect(client.login(tt.account, tt.password), http.StatusFound, "/dashboard/home")
+			if resp, _ := client.get("/dashboard/home"); resp.Header.Get("Content-Language") != tt.lang {
+				t.Errorf("%s: Content-Language = %q, %s bekleniyordu", tt.account, resp.Header.Get("Content-Language"), tt.lang)
+			}
+		}
+	})
+}
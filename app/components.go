@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/pkg/lifecycle"
+	"zatrano/pkg/logs"
+	"zatrano/routes"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// importCleanupInterval süresi dolmuş içe aktarma dosyalarının silinme
+// sıklığıdır.
+const importCleanupInterval = 10 * time.Minute
+
+// registerComponents bileşenleri başlatma sırasına göre kaydeder; kapatma
+// sırasında ters sırayla durdurulurlar (önce readiness, en son logger).
+func (a *App) registerComponents(manager *lifecycle.Manager) {
+	manager.Append(lifecycle.Hook{
+		Name: "logger",
+		OnStop: func(ctx context.Context) error {
+			logs.SyncLogger()
+			return nil
+		},
+	})
+
+	manager.Append(lifecycle.Hook{
+		Name: "database",
+		OnStop: func(ctx context.Context) error {
+			return configs.CloseDB(a.DB)
+		},
+	})
+
+	manager.Append(lifecycle.Hook{
+		Name: "session_store",
+		OnStop: func(ctx context.Context) error {
+			if a.SessionStore == nil || a.SessionStore.Storage == nil {
+				return nil
+			}
+			return a.SessionStore.Storage.Close()
+		},
+	})
+
+	if days := a.Config.Trash.RetentionDays; days > 0 {
+		retention := time.Duration(days) * 24 * time.Hour
+		interval := time.Duration(a.Config.Trash.PurgeIntervalMinutes) * time.Minute
+		manager.Append(lifecycle.Periodic("trash_purge", interval, func(ctx context.Context) {
+			_, _ = a.Services.User.PurgeTrashedUsers(ctx, time.Now().Add(-retention))
+		}))
+	}
+
+	// Yüklenen içe aktarma dosyaları şifre içerir; yarım bırakılanlar yeni bir
+	// yükleme beklenmeden silinir. İlk tur önceki çalışmadan kalanları temizler.
+	manager.Append(lifecycle.Periodic("import_cleanup", importCleanupInterval, func(context.Context) {
+		a.userImportHandler.CleanupUploads()
+	}))
+
+	if port := a.Config.Metrics.Port; port != 0 {
+		metricsServer := routes.NewMetricsApp(a.healthHandler)
+		manager.Append(httpServerHook(manager, "metrics_server", metricsServer, ":"+strconv.Itoa(port)))
+	}
+
+	port := strconv.Itoa(a.Config.App.Port)
+	a.Server.Hooks().OnListen(func(fiber.ListenData) error {
+		a.Health.MarkReady()
+		return nil
+	})
+	manager.Append(httpServerHook(manager, "http_server", a.Server, ":"+port))
+
+	manager.Append(lifecycle.Hook{
+		Name: "readiness",
+		OnStop: func(ctx context.Context) error {
+			a.Health.MarkShuttingDown()
+
+			drainSeconds := a.Config.Shutdown.DrainSeconds
+			if drainSeconds <= 0 {
+				return nil
+			}
+			logs.Log.Info("Hazırlık durumu kapatıldı, yük dengeleyicinin trafiği boşaltması bekleniyor",
+				zap.Int("drain_seconds", drainSeconds),
+			)
+			select {
+			case <-time.After(time.Duration(drainSeconds) * time.Second):
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+func httpServerHook(manager *lifecycle.Manager, name string, server *fiber.App, address string) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				logs.Log.Info("Sunucu başlatılıyor",
+					zap.String("component", name),
+					zap.String("address", "http://localhost"+address),
+				)
+				if err := server.Listen(address); err != nil {
+					logs.Log.Error("Sunucu dinlenemedi",
+						zap.String("component", name),
+						zap.String("address", address),
+						zap.Error(err),
+					)
+					manager.Fail(name, err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			timeout := time.Second
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+			if err := server.ShutdownWithTimeout(timeout); err != nil {
+				if errors.Is(err, context.DeadlineExceeded) {
+					logs.Log.Warn("Sunucu kapatma süresi doldu, bekleyen istekler kesildi", zap.String("component", name))
+				}
+				return err
+			}
+			logs.Log.Info("Sunucu başarıyla kapatıldı", zap.String("component", name))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,306 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/text/language"
+)
+
+// Config uygulamanın tüm ayarlarını tek yerde toplar. Değerler Load ile şu
+// öncelik sırasına göre doldurulur (sonraki öncekini ezer):
+// varsayılanlar < YAML/TOML yapılandırma dosyası < .env dosyası < ortam değişkenleri.
+type Config struct {
+	App      AppConfig      `yaml:"app" toml:"app"`
+	Log      LogConfig      `yaml:"log" toml:"log"`
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	Session  SessionConfig  `yaml:"session" toml:"session"`
+	Metrics  MetricsConfig  `yaml:"metrics" toml:"metrics"`
+	Shutdown ShutdownConfig `yaml:"shutdown" toml:"shutdown"`
+	Trash    TrashConfig    `yaml:"trash" toml:"trash"`
+	Locale   LocaleConfig   `yaml:"locale" toml:"locale"`
+}
+
+type AppConfig struct {
+	Env  string `yaml:"env" toml:"env" env:"APP_ENV"`
+	Port int    `yaml:"port" toml:"port" env:"APP_PORT"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+}
+
+type DatabaseConfig struct {
+	URL                    string        `yaml:"url" toml:"url" env:"DATABASE_URL" secret:"url"`
+	Host                   string        `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port                   int           `yaml:"port" toml:"port" env:"DB_PORT"`
+	User                   string        `yaml:"user" toml:"user" env:"DB_USERNAME"`
+	Password               string        `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name                   string        `yaml:"name" toml:"name" env:"DB_DATABASE"`
+	SSLMode                string        `yaml:"ssl_mode" toml:"ssl_mode" env:"DB_SSL_MODE"`
+	TimeZone               string        `yaml:"timezone" toml:"timezone" env:"DB_TIMEZONE"`
+	MaxIdleConns           int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	MaxOpenConns           int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	ConnMaxLifetimeMinutes int           `yaml:"conn_max_lifetime_minutes" toml:"conn_max_lifetime_minutes" env:"DB_CONN_MAX_LIFETIME_MINUTES"`
+	LogLevel               string        `yaml:"log_level" toml:"log_level" env:"DB_LOG_LEVEL"`
+	SlowQueryThresholdMs   int           `yaml:"slow_query_threshold_ms" toml:"slow_query_threshold_ms" env:"DB_SLOW_QUERY_THRESHOLD_MS"`
+	ApplicationName        string        `yaml:"application_name" toml:"application_name" env:"DB_APPLICATION_NAME"`
+	StatementTimeoutMs     int           `yaml:"statement_timeout_ms" toml:"statement_timeout_ms" env:"DB_STATEMENT_TIMEOUT_MS"`
+	SearchPath             string        `yaml:"search_path" toml:"search_path" env:"DB_SEARCH_PATH"`
+	QueryTimeoutMs         int           `yaml:"query_timeout_ms" toml:"query_timeout_ms" env:"DB_QUERY_TIMEOUT_MS"`
+	TxMaxRetries           int           `yaml:"tx_max_retries" toml:"tx_max_retries" env:"DB_TX_MAX_RETRIES"`
+	Replica                ReplicaConfig `yaml:"replica" toml:"replica"`
+}
+
+// ReplicaConfig okuma replikalarını tanımlar. URLs ve Nodes boşsa tüm sorgular
+// birincil veritabanına gider. Her replikanın kendi bağlantı havuzu vardır;
+// URLs'deki replikalar buradaki ortak havuz ayarlarını kullanır, Nodes ile
+// (yalnızca YAML/TOML) replika başına ayrı ayarlar verilebilir.
+type ReplicaConfig struct {
+	URLs                   []string      `yaml:"urls" toml:"urls" env:"DB_REPLICA_URLS" secret:"url"`
+	MaxIdleConns           int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_REPLICA_MAX_IDLE_CONNS"`
+	MaxOpenConns           int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_REPLICA_MAX_OPEN_CONNS"`
+	ConnMaxLifetimeMinutes int           `yaml:"conn_max_lifetime_minutes" toml:"conn_max_lifetime_minutes" env:"DB_REPLICA_CONN_MAX_LIFETIME_MINUTES"`
+	Nodes                  []ReplicaNode `yaml:"nodes" toml:"nodes"`
+}
+
+// ReplicaNode kendi havuz ayarlarıyla tanımlanan bir replikadır; boş
+// bırakılan ayarlar ReplicaConfig'deki ortak değerlerden alınır.
+type ReplicaNode struct {
+	URL                    string `yaml:"url" toml:"url" secret:"url"`
+	MaxIdleConns           *int   `yaml:"max_idle_conns,omitempty" toml:"max_idle_conns,omitempty"`
+	MaxOpenConns           *int   `yaml:"max_open_conns,omitempty" toml:"max_open_conns,omitempty"`
+	ConnMaxLifetimeMinutes *int   `yaml:"conn_max_lifetime_minutes,omitempty" toml:"conn_max_lifetime_minutes,omitempty"`
+}
+
+// ReplicaPool bir replikanın bağlantı adresi ve çözümlenmiş havuz ayarlarıdır.
+// Name hata mesajlarında ve metriklerde replikayı tanımlar.
+type ReplicaPool struct {
+	Name                   string
+	URL                    string
+	MaxIdleConns           int
+	MaxOpenConns           int
+	ConnMaxLifetimeMinutes int
+}
+
+// Pools URLs ve Nodes'taki replikaları, ortak ayarlar uygulanmış havuz
+// ayarlarıyla birlikte tanımlandıkları sırayla döner.
+func (r ReplicaConfig) Pools() []ReplicaPool {
+	pools := make([]ReplicaPool, 0, len(r.URLs)+len(r.Nodes))
+	for i, url := range r.URLs {
+		pools = append(pools, ReplicaPool{
+			Name:                   fmt.Sprintf("DB_REPLICA_URLS[%d]", i),
+			URL:                    url,
+			MaxIdleConns:           r.MaxIdleConns,
+			MaxOpenConns:           r.MaxOpenConns,
+			ConnMaxLifetimeMinutes: r.ConnMaxLifetimeMinutes,
+		})
+	}
+	for i, node := range r.Nodes {
+		pools = append(pools, ReplicaPool{
+			Name:                   fmt.Sprintf("database.replica.nodes[%d]", i),
+			URL:                    node.URL,
+			MaxIdleConns:           intOr(node.MaxIdleConns, r.MaxIdleConns),
+			MaxOpenConns:           intOr(node.MaxOpenConns, r.MaxOpenConns),
+			ConnMaxLifetimeMinutes: intOr(node.ConnMaxLifetimeMinutes, r.ConnMaxLifetimeMinutes),
+		})
+	}
+	return pools
+}
+
+func intOr(value *int, fallback int) int {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+type SessionConfig struct {
+	ExpirationHours int `yaml:"expiration_hours" toml:"expiration_hours" env:"SESSION_EXPIRATION_HOURS"`
+}
+
+type MetricsConfig struct {
+	Port     int    `yaml:"port" toml:"port" env:"METRICS_PORT"`
+	Username string `yaml:"username" toml:"username" env:"METRICS_USERNAME"`
+	Password string `yaml:"password" toml:"password" env:"METRICS_PASSWORD" secret:"true"`
+}
+
+// ShutdownConfig kapatma sürelerini belirler. DrainSeconds, readiness
+// kapatıldıktan sonra bileşenler durdurulmadan önce beklenen süredir ve
+// TimeoutSeconds'a dahil değildir: toplam kapanma süresi en fazla
+// DrainSeconds + TimeoutSeconds olur.
+type ShutdownConfig struct {
+	DrainSeconds   int `yaml:"drain_seconds" toml:"drain_seconds" env:"SHUTDOWN_DRAIN_SECONDS"`
+	TimeoutSeconds int `yaml:"timeout_seconds" toml:"timeout_seconds" env:"SHUTDOWN_TIMEOUT_SECONDS"`
+}
+
+// TrashConfig silinen kayıtların çöp kutusunda ne kadar tutulacağını belirler.
+// RetentionDays 0 ise otomatik kalıcı silme yapılmaz.
+type TrashConfig struct {
+	RetentionDays        int `yaml:"retention_days" toml:"retention_days" env:"TRASH_RETENTION_DAYS"`
+	PurgeIntervalMinutes int `yaml:"purge_interval_minutes" toml:"purge_interval_minutes" env:"TRASH_PURGE_INTERVAL_MINUTES"`
+}
+
+// LocaleConfig arayüz dillerini belirler. Dir içindeki her <dil>.json veya
+// <dil>.toml dosyası bir dil kataloğudur; Default, isteğin dili
+// belirlenemediğinde ve bir dilde eksik olan mesajlar için kullanılır.
+// Timezone, saat dilimi seçmemiş kullanıcılara tarihlerin gösterildiği IANA
+// saat dilimidir; veritabanındaki zamanlar UTC'dir.
+type LocaleConfig struct {
+	Default  string `yaml:"default" toml:"default" env:"LOCALE_DEFAULT"`
+	Dir      string `yaml:"dir" toml:"dir" env:"LOCALE_DIR"`
+	Timezone string `yaml:"timezone" toml:"timezone" env:"LOCALE_TIMEZONE"`
+}
+
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			Env:  "development",
+			Port: 3000,
+		},
+		Database: DatabaseConfig{
+			Host:                   "localhost",
+			Port:                   5432,
+			User:                   "postgres",
+			Name:                   "myapp",
+			SSLMode:                "disable",
+			TimeZone:               "UTC",
+			MaxIdleConns:           10,
+			MaxOpenConns:           100,
+			ConnMaxLifetimeMinutes: 60,
+			LogLevel:               "info",
+			SlowQueryThresholdMs:   200,
+			ApplicationName:        "zatrano",
+			QueryTimeoutMs:         10000,
+			TxMaxRetries:           3,
+			Replica: ReplicaConfig{
+				MaxIdleConns:           10,
+				MaxOpenConns:           100,
+				ConnMaxLifetimeMinutes: 60,
+			},
+		},
+		Session: SessionConfig{
+			ExpirationHours: 24,
+		},
+		Shutdown: ShutdownConfig{
+			TimeoutSeconds: 15,
+		},
+		Trash: TrashConfig{
+			RetentionDays:        30,
+			PurgeIntervalMinutes: 60,
+		},
+		Locale: LocaleConfig{
+			Default:  "tr",
+			Dir:      "./locales",
+			Timezone: "Europe/Istanbul",
+		},
+	}
+}
+
+func (c *Config) IsProduction() bool {
+	return c.App.Env == "production"
+}
+
+// Validate tüm alanları kontrol eder ve bulunan hataların hepsini birlikte döner.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.App.Env == "development" || c.App.Env == "production",
+		"APP_ENV 'development' veya 'production' olmalı: %q", c.App.Env)
+	check(validPort(c.App.Port), "APP_PORT 1-65535 aralığında olmalı: %d", c.App.Port)
+	if c.Log.Level != "" {
+		var level zapcore.Level
+		check(level.Set(c.Log.Level) == nil, "LOG_LEVEL geçersiz: %q", c.Log.Level)
+	}
+
+	if c.Database.URL != "" {
+		if _, err := c.Database.parseURL(); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		check(c.Database.Host != "", "DB_HOST boş olamaz")
+		check(validPort(c.Database.Port), "DB_PORT 1-65535 aralığında olmalı: %d", c.Database.Port)
+		check(c.Database.User != "", "DB_USERNAME boş olamaz")
+		check(c.Database.Name != "", "DB_DATABASE boş olamaz")
+		check(oneOf(c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full"),
+			"DB_SSL_MODE geçersiz: %q", c.Database.SSLMode)
+	}
+	for _, raw := range c.Database.Replica.URLs {
+		replica := c.Database
+		replica.URL = raw
+		if _, err := replica.parseURL(); err != nil {
+			errs = append(errs, fmt.Errorf("DB_REPLICA_URLS: %w", err))
+		}
+	}
+	if len(c.Database.Replica.URLs) > 0 {
+		check(c.Database.Replica.MaxIdleConns >= 0, "DB_REPLICA_MAX_IDLE_CONNS negatif olamaz: %d", c.Database.Replica.MaxIdleConns)
+		check(c.Database.Replica.MaxOpenConns > 0, "DB_REPLICA_MAX_OPEN_CONNS pozitif olmalı: %d", c.Database.Replica.MaxOpenConns)
+		check(c.Database.Replica.ConnMaxLifetimeMinutes >= 0,
+			"DB_REPLICA_CONN_MAX_LIFETIME_MINUTES negatif olamaz: %d", c.Database.Replica.ConnMaxLifetimeMinutes)
+	}
+	for _, pool := range c.Database.Replica.Pools()[len(c.Database.Replica.URLs):] {
+		replica := c.Database
+		replica.URL = pool.URL
+		if _, err := replica.parseURL(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", pool.Name, err))
+		}
+		check(pool.MaxIdleConns >= 0, "%s: max_idle_conns negatif olamaz: %d", pool.Name, pool.MaxIdleConns)
+		check(pool.MaxOpenConns > 0, "%s: max_open_conns pozitif olmalı: %d", pool.Name, pool.MaxOpenConns)
+		check(pool.MaxIdleConns <= pool.MaxOpenConns,
+			"%s: max_idle_conns (%d) max_open_conns (%d) değerini aşamaz", pool.Name, pool.MaxIdleConns, pool.MaxOpenConns)
+		check(pool.ConnMaxLifetimeMinutes >= 0, "%s: conn_max_lifetime_minutes negatif olamaz: %d", pool.Name, pool.ConnMaxLifetimeMinutes)
+	}
+	check(c.Database.QueryTimeoutMs >= 0, "DB_QUERY_TIMEOUT_MS negatif olamaz: %d", c.Database.QueryTimeoutMs)
+	check(c.Database.TxMaxRetries >= 0, "DB_TX_MAX_RETRIES negatif olamaz: %d", c.Database.TxMaxRetries)
+	check(c.Database.StatementTimeoutMs >= 0, "DB_STATEMENT_TIMEOUT_MS negatif olamaz: %d", c.Database.StatementTimeoutMs)
+	check(oneOf(c.Database.LogLevel, "silent", "error", "warn", "info"),
+		"DB_LOG_LEVEL 'silent', 'error', 'warn' veya 'info' olmalı: %q", c.Database.LogLevel)
+	check(c.Database.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS negatif olamaz: %d", c.Database.MaxIdleConns)
+	check(c.Database.MaxOpenConns > 0, "DB_MAX_OPEN_CONNS pozitif olmalı: %d", c.Database.MaxOpenConns)
+	check(c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"DB_MAX_IDLE_CONNS (%d) DB_MAX_OPEN_CONNS (%d) değerini aşamaz", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	check(c.Database.ConnMaxLifetimeMinutes >= 0, "DB_CONN_MAX_LIFETIME_MINUTES negatif olamaz: %d", c.Database.ConnMaxLifetimeMinutes)
+	check(c.Database.SlowQueryThresholdMs >= 0, "DB_SLOW_QUERY_THRESHOLD_MS negatif olamaz: %d", c.Database.SlowQueryThresholdMs)
+
+	check(c.Session.ExpirationHours > 0, "SESSION_EXPIRATION_HOURS pozitif olmalı: %d", c.Session.ExpirationHours)
+
+	check(c.Metrics.Port == 0 || validPort(c.Metrics.Port), "METRICS_PORT 1-65535 aralığında olmalı: %d", c.Metrics.Port)
+	check(c.Metrics.Port == 0 || c.Metrics.Port != c.App.Port, "METRICS_PORT, APP_PORT ile aynı olamaz: %d", c.Metrics.Port)
+	check((c.Metrics.Username == "") == (c.Metrics.Password == ""),
+		"METRICS_USERNAME ve METRICS_PASSWORD birlikte tanımlanmalı")
+
+	check(c.Shutdown.DrainSeconds >= 0, "SHUTDOWN_DRAIN_SECONDS negatif olamaz: %d", c.Shutdown.DrainSeconds)
+	check(c.Shutdown.TimeoutSeconds > 0, "SHUTDOWN_TIMEOUT_SECONDS pozitif olmalı: %d", c.Shutdown.TimeoutSeconds)
+
+	check(c.Trash.RetentionDays >= 0, "TRASH_RETENTION_DAYS negatif olamaz: %d", c.Trash.RetentionDays)
+	check(c.Trash.PurgeIntervalMinutes > 0, "TRASH_PURGE_INTERVAL_MINUTES pozitif olmalı: %d", c.Trash.PurgeIntervalMinutes)
+
+	_, err := language.Parse(c.Locale.Default)
+	check(err == nil, "LOCALE_DEFAULT geçerli bir dil kodu olmalı: %q", c.Locale.Default)
+	check(c.Locale.Dir != "", "LOCALE_DIR boş olamaz")
+	_, err = time.LoadLocation(c.Locale.Timezone)
+	check(c.Locale.Timezone != "" && err == nil, "LOCALE_TIMEZONE geçerli bir IANA saat dilimi olmalı: %q", c.Locale.Timezone)
+
+	return errors.Join(errs...)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return true
+		}
+	}
+	return false
+}
@@ -33,7 +33,7 @@ func SetupCSRF() fiber.Handler {
 				zap.String("path", c.Path()),
 				zap.String("method", c.Method()),
 			)
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güvenlik doğrulaması başarısız oldu. Lütfen sayfayı yenileyip tekrar deneyin.")
+			flashmessages.Error(c, "Güvenlik doğrulaması başarısız oldu. Lütfen sayfayı yenileyip tekrar deneyin.")
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		},
 
@@ -1,116 +1,181 @@
 package configs
 
 import (
-	"os"
-	"strconv"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
-	"zatrano/pkg/env"
+	"zatrano/pkg/audit"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
 
-	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
-var DB *gorm.DB
+func OpenDB(cfg *Config) (*gorm.DB, error) {
+	dbConfig := cfg.Database
 
-type DatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
-	TimeZone string
-}
-
-func InitDB() {
-	err := godotenv.Load()
-	if err != nil {
-		logs.SLog.Warnw(".env dosyası yüklenemedi, sistem ortam değişkenleri kullanılacak (eğer varsa)", "error", err)
-	} else {
-		logs.SLog.Info(".env dosyası başarıyla yüklendi")
-	}
+	logs.Log.Info("Database configuration loaded", dbConfig.logFields()...)
 
-	portStr := env.GetEnvWithDefault("DB_PORT", "5432")
-	port, err := strconv.Atoi(portStr)
+	dsn, err := dbConfig.DSN()
 	if err != nil {
-		logs.SLog.Fatalw("Invalid DB_PORT environment variable",
-			"value", portStr,
-			"error", err,
-		)
-	}
-
-	dbConfig := DatabaseConfig{
-		Host:     env.GetEnvWithDefault("DB_HOST", "localhost"),
-		Port:     port,
-		User:     env.GetEnvWithDefault("DB_USERNAME", "postgres"),
-		Password: env.GetEnvWithDefault("DB_PASSWORD", ""),
-		Name:     env.GetEnvWithDefault("DB_DATABASE", "myapp"),
-		SSLMode:  env.GetEnvWithDefault("DB_SSL_MODE", "disable"),
-		TimeZone: env.GetEnvWithDefault("DB_TIMEZONE", "UTC"),
+		return nil, err
 	}
 
-	logs.Log.Info("Database configuration loaded",
-		zap.String("host", dbConfig.Host),
-		zap.Int("port", dbConfig.Port),
-		zap.String("user", dbConfig.User),
-		zap.String("database", dbConfig.Name),
-		zap.String("sslmode", dbConfig.SSLMode),
-		zap.String("timezone", dbConfig.TimeZone),
-	)
-
-	dsn := "host=" + dbConfig.Host +
-		" user=" + dbConfig.User +
-		" password=" + dbConfig.Password +
-		" dbname=" + dbConfig.Name +
-		" port=" + strconv.Itoa(dbConfig.Port) +
-		" sslmode=" + dbConfig.SSLMode +
-		" TimeZone=" + dbConfig.TimeZone
-
-	var gormerr error
-	DB, gormerr = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(getGormLogLevel()),
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logs.NewGormLogger(logs.GormLoggerConfig{
+			LogLevel:                  getGormLogLevel(dbConfig.LogLevel),
+			SlowThreshold:             time.Duration(dbConfig.SlowQueryThresholdMs) * time.Millisecond,
+			RedactParams:              cfg.IsProduction(),
+			IgnoreRecordNotFoundError: true,
+		}),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
 	})
-
-	if gormerr != nil {
-		logs.Log.Fatal("Failed to connect to database",
-			zap.String("host", dbConfig.Host),
-			zap.Int("port", dbConfig.Port),
-			zap.String("user", dbConfig.User),
-			zap.String("database", dbConfig.Name),
-			zap.Error(gormerr),
-		)
+	if err != nil {
+		logs.Log.Error("Failed to connect to database", append(dbConfig.logFields(), zap.Error(err))...)
+		return nil, err
 	}
 
-	sqlDB, err := DB.DB()
+	sqlDB, err := db.DB()
 	if err != nil {
-		logs.Log.Fatal("Failed to get underlying sql.DB instance", zap.Error(err))
+		logs.Log.Error("Failed to get underlying sql.DB instance", zap.Error(err))
+		return nil, err
 	}
 
-	maxIdleConns := env.GetEnvAsInt("DB_MAX_IDLE_CONNS", 10)
-	maxOpenConns := env.GetEnvAsInt("DB_MAX_OPEN_CONNS", 100)
-	connMaxLifetimeMinutes := env.GetEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 60)
+	maxIdleConns := dbConfig.MaxIdleConns
+	maxOpenConns := dbConfig.MaxOpenConns
+	connMaxLifetimeMinutes := dbConfig.ConnMaxLifetimeMinutes
 
 	sqlDB.SetMaxIdleConns(maxIdleConns)
 	sqlDB.SetMaxOpenConns(maxOpenConns)
 	sqlDB.SetConnMaxLifetime(time.Duration(connMaxLifetimeMinutes) * time.Minute)
 
+	if err := metrics.RegisterDBStats(sqlDB, dbConfig.metricsName()); err != nil {
+		logs.Log.Warn("Veritabanı havuzu metrikleri kaydedilemedi", zap.Error(err))
+	}
+
+	if err := registerReplicas(db, dbConfig); err != nil {
+		logs.Log.Error("Okuma replikaları yapılandırılamadı", zap.Error(err))
+		return nil, err
+	}
+
+	if err := db.Use(audit.NewPlugin()); err != nil {
+		logs.Log.Error("Audit eklentisi kaydedilemedi", zap.Error(err))
+		return nil, err
+	}
+
 	logs.Log.Info("Database connection established successfully",
 		zap.Int("max_idle_conns", maxIdleConns),
 		zap.Int("max_open_conns", maxOpenConns),
 		zap.Int("conn_max_lifetime_minutes", connMaxLifetimeMinutes),
 	)
+	return db, nil
 }
 
-func getGormLogLevel() logger.LogLevel {
-	switch os.Getenv("DB_LOG_LEVEL") {
+// registerReplicas tanımlı okuma replikalarını dbresolver ile kaydeder. Okuma
+// sorguları replikalar arasında rastgele dağıtılır; yazmalar ve transaction
+// içindeki tüm sorgular birincil veritabanında kalır. dbresolver tek bir
+// global resolver'a izin verdiği ve havuz ayarlarını tüm replikalara birlikte
+// uyguladığı için her replikanın havuzu burada ayrı açılıp ayarlanır.
+func registerReplicas(db *gorm.DB, dbConfig DatabaseConfig) error {
+	replicas := dbConfig.Replica.Pools()
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	pools := &replicaPools{}
+	dialectors := make([]gorm.Dialector, 0, len(replicas))
+	for i, replica := range replicas {
+		sqlDB, err := openReplica(dbConfig, replica)
+		if err != nil {
+			_ = pools.Close()
+			return fmt.Errorf("%s: %w", replica.Name, err)
+		}
+		pools.dbs = append(pools.dbs, sqlDB)
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: sqlDB}))
+
+		if err := metrics.RegisterDBStats(sqlDB, fmt.Sprintf("%s_replica_%d", dbConfig.metricsName(), i)); err != nil {
+			logs.Log.Warn("Replika havuzu metrikleri kaydedilemedi", zap.String("replica", replica.Name), zap.Error(err))
+		}
+		logs.Log.Info("Okuma replikası yapılandırıldı",
+			zap.String("replica", replica.Name),
+			zap.Int("max_idle_conns", replica.MaxIdleConns),
+			zap.Int("max_open_conns", replica.MaxOpenConns),
+			zap.Int("conn_max_lifetime_minutes", replica.ConnMaxLifetimeMinutes),
+		)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   dbresolver.RandomPolicy{},
+	})
+	if err := db.Use(resolver); err != nil {
+		_ = pools.Close()
+		return err
+	}
+	if err := db.Use(pools); err != nil {
+		_ = pools.Close()
+		return err
+	}
+
+	logs.Log.Info("Okuma replikaları yapılandırıldı", zap.Int("replica_count", len(dialectors)))
+	return nil
+}
+
+func openReplica(dbConfig DatabaseConfig, pool ReplicaPool) (*sql.DB, error) {
+	replica := dbConfig
+	replica.URL = pool.URL
+	dsn, err := replica.DSN()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(pool.ConnMaxLifetimeMinutes) * time.Minute)
+	return sqlDB, nil
+}
+
+const replicaPoolsPluginName = "zatrano:replica_pools"
+
+// replicaPools replikalar için açılan havuzları tutar. dbresolver havuzlara
+// erişim vermediği için gorm eklentisi olarak db'ye bağlanır; CloseDB
+// birincil bağlantıyla birlikte bunları da kapatır.
+type replicaPools struct {
+	dbs []*sql.DB
+}
+
+func (p *replicaPools) Name() string {
+	return replicaPoolsPluginName
+}
+
+func (p *replicaPools) Initialize(*gorm.DB) error {
+	return nil
+}
+
+func (p *replicaPools) Close() error {
+	var errs []error
+	for _, sqlDB := range p.dbs {
+		if err := sqlDB.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func getGormLogLevel(level string) logger.LogLevel {
+	switch strings.ToLower(level) {
 	case "silent":
 		return logger.Silent
 	case "error":
@@ -122,20 +187,19 @@ func getGormLogLevel() logger.LogLevel {
 	}
 }
 
-func GetDB() *gorm.DB {
-	if DB == nil {
-		logs.Log.Fatal("Database connection not initialized. Call InitDB() first.")
-	}
-	return DB
-}
-
-func CloseDB() error {
-	if DB == nil {
+func CloseDB(db *gorm.DB) error {
+	if db == nil {
 		logs.SLog.Info("Database connection already closed or not initialized.")
 		return nil
 	}
 
-	sqlDB, err := DB.DB()
+	if pools, ok := db.Config.Plugins[replicaPoolsPluginName].(*replicaPools); ok {
+		if err := pools.Close(); err != nil {
+			logs.Log.Error("Replika bağlantıları kapatılırken hata oluştu", zap.Error(err))
+		}
+	}
+
+	sqlDB, err := db.DB()
 	if err != nil {
 		logs.Log.Error("Failed to get database instance for closing", zap.Error(err))
 		return err
@@ -148,6 +212,5 @@ func CloseDB() error {
 	}
 
 	logs.SLog.Info("Database connection closed successfully.")
-	DB = nil
 	return nil
 }
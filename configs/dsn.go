@@ -0,0 +1,116 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var dsnValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// DSN bağlantı dizesini üretir. DATABASE_URL tanımlıysa o kullanılır ve
+// ek seçenekler (application_name, statement_timeout, search_path, TimeZone)
+// URL'de yoksa sorgu parametresi olarak eklenir; aksi halde ayrı alanlardan
+// anahtar=değer biçiminde, değerler libpq kurallarına göre tırnaklanarak oluşturulur.
+func (d DatabaseConfig) DSN() (string, error) {
+	if d.URL != "" {
+		u, err := d.parseURL()
+		if err != nil {
+			return "", err
+		}
+		query := u.Query()
+		for _, opt := range d.runtimeParams() {
+			if query.Get(opt[0]) == "" {
+				query.Set(opt[0], opt[1])
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	pairs := [][2]string{
+		{"host", d.Host},
+		{"port", strconv.Itoa(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+	}
+	pairs = append(pairs, d.runtimeParams()...)
+
+	parts := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		parts = append(parts, p[0]+"="+quoteDSNValue(p[1]))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+func (d DatabaseConfig) runtimeParams() [][2]string {
+	var params [][2]string
+	if d.TimeZone != "" {
+		params = append(params, [2]string{"TimeZone", d.TimeZone})
+	}
+	if d.ApplicationName != "" {
+		params = append(params, [2]string{"application_name", d.ApplicationName})
+	}
+	if d.StatementTimeoutMs > 0 {
+		params = append(params, [2]string{"statement_timeout", strconv.Itoa(d.StatementTimeoutMs)})
+	}
+	if d.SearchPath != "" {
+		params = append(params, [2]string{"search_path", d.SearchPath})
+	}
+	return params
+}
+
+func (d DatabaseConfig) parseURL() (*url.URL, error) {
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return nil, errors.New("DATABASE_URL çözümlenemedi")
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("DATABASE_URL şeması postgres:// veya postgresql:// olmalı: %q", u.Scheme)
+	}
+	return u, nil
+}
+
+// logFields bağlantı hedefini şifre içermeden loglamak için alanları döner.
+func (d DatabaseConfig) logFields() []zap.Field {
+	if d.URL != "" {
+		if u, err := d.parseURL(); err == nil {
+			return []zap.Field{
+				zap.String("url", u.Redacted()),
+				zap.String("application_name", d.ApplicationName),
+			}
+		}
+	}
+	return []zap.Field{
+		zap.String("host", d.Host),
+		zap.Int("port", d.Port),
+		zap.String("user", d.User),
+		zap.String("database", d.Name),
+		zap.String("sslmode", d.SSLMode),
+		zap.String("timezone", d.TimeZone),
+		zap.String("application_name", d.ApplicationName),
+	}
+}
+
+// metricsName bağlantı havuzu metriklerinde kullanılacak veritabanı adını döner.
+func (d DatabaseConfig) metricsName() string {
+	if d.URL != "" {
+		if u, err := d.parseURL(); err == nil {
+			return strings.TrimPrefix(u.Path, "/")
+		}
+	}
+	return d.Name
+}
+
+func quoteDSNValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n'\\") {
+		return value
+	}
+	return "'" + dsnValueEscaper.Replace(value) + "'"
+}
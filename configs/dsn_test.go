@@ -0,0 +1,170 @@
+package configs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuoteDSNValue(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"", "''"},
+		{"with space", "'with space'"},
+		{"tab\there", "'tab\there'"},
+		{"new\nline", "'new\nline'"},
+		{"it's", `'it\'s'`},
+		{`back\slash`, `'back\\slash'`},
+		{`a 'b' \c`, `'a \'b\' \\c'`},
+		{"p@ss=w0rd;", "p@ss=w0rd;"},
+	}
+	for _, tt := range tests {
+		if got := quoteDSNValue(tt.in); got != tt.want {
+			t.Errorf("quoteDSNValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDSNFromFields(t *testing.T) {
+	d := Default().Database
+	d.Password = "it's a secret"
+	d.Name = ""
+	d.StatementTimeoutMs = 5000
+	d.SearchPath = "app, public"
+
+	dsn, err := d.DSN()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `host=localhost port=5432 user=postgres password='it\'s a secret' dbname='' sslmode=disable ` +
+		`TimeZone=UTC application_name=zatrano statement_timeout=5000 search_path='app, public'`
+	if dsn != want {
+		t.Errorf("DSN:\n got  %s\n want %s", dsn, want)
+	}
+}
+
+func TestDSNFromURL(t *testing.T) {
+	tests := []struct {
+		name, url string
+		want      []string
+		wantErr   string
+	}{
+		{
+			name: "çalışma parametreleri eklenir",
+			url:  "postgres://app:p%40ss@db:5432/app?sslmode=require",
+			want: []string{"postgres://app:p%40ss@db:5432/app?", "sslmode=require", "application_name=zatrano", "TimeZone=UTC"},
+		},
+		{
+			name: "URL'deki parametre korunur",
+			url:  "postgresql://db/app?application_name=worker",
+			want: []string{"postgresql://db/app?", "application_name=worker", "TimeZone=UTC"},
+		},
+		{name: "yanlış şema", url: "mysql://db/app", wantErr: "postgres:// veya postgresql://"},
+		{name: "çözümlenemeyen", url: "postgres://db:port/app", wantErr: "DATABASE_URL çözümlenemedi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Default().Database
+			d.URL = tt.url
+			dsn, err := d.DSN()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DSN hatası = %v, %q bekleniyordu", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, part := range tt.want {
+				if !strings.Contains(dsn, part) {
+					t.Errorf("DSN %q, %q içermiyor", dsn, part)
+				}
+			}
+			if strings.Count(dsn, "application_name=") != 1 {
+				t.Errorf("application_name tekrarlandı: %q", dsn)
+			}
+		})
+	}
+}
+
+func TestValidateDatabaseURL(t *testing.T) {
+	cfg := Default()
+	cfg.Database.URL = "mysql://db/app"
+	cfg.Database.Host = ""
+	cfg.Database.Replica.URLs = []string{"postgres://replica/app", "http://replica/app"}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate: hata bekleniyordu")
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "DB_HOST") {
+		t.Errorf("DATABASE_URL varken ayrı alanlar doğrulanmamalı: %v", err)
+	}
+	if strings.Count(msg, "postgres:// veya postgresql://") != 2 || !strings.Contains(msg, "DB_REPLICA_URLS") {
+		t.Errorf("birincil ve replika URL hataları bekleniyordu: %v", err)
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	t.Run("gizli alan dosyadan okunur", func(t *testing.T) {
+		clearEnv(t)
+		dir := t.TempDir()
+		t.Chdir(dir)
+		t.Setenv("DB_PASSWORD_FILE", writeFile(t, dir, "db_password", "from-file\r\n"))
+		t.Setenv("DATABASE_URL_FILE", writeFile(t, dir, "db_url", "postgres://app:pw@db/app\n"))
+
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Database.Password != "from-file" {
+			t.Errorf("Password = %q, want %q", cfg.Database.Password, "from-file")
+		}
+		if cfg.Database.URL != "postgres://app:pw@db/app" {
+			t.Errorf("URL = %q", cfg.Database.URL)
+		}
+	})
+
+	t.Run("gizli olmayan alan için _FILE yok sayılır", func(t *testing.T) {
+		clearEnv(t)
+		dir := t.TempDir()
+		t.Chdir(dir)
+		t.Setenv("DB_HOST_FILE", writeFile(t, dir, "db_host", "filehost"))
+
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Database.Host != "localhost" {
+			t.Errorf("Host = %q, DB_HOST_FILE yok sayılmalıydı", cfg.Database.Host)
+		}
+	})
+
+	t.Run("değer ve dosya birlikte tanımlanamaz", func(t *testing.T) {
+		clearEnv(t)
+		dir := t.TempDir()
+		t.Chdir(dir)
+		t.Setenv("DB_PASSWORD", "inline")
+		t.Setenv("DB_PASSWORD_FILE", writeFile(t, dir, "db_password", "from-file"))
+
+		_, err := Load("")
+		if err == nil || !strings.Contains(err.Error(), "DB_PASSWORD ve DB_PASSWORD_FILE birlikte tanımlanamaz") {
+			t.Errorf("Load hatası = %v", err)
+		}
+	})
+
+	t.Run("okunamayan dosya", func(t *testing.T) {
+		clearEnv(t)
+		dir := t.TempDir()
+		t.Chdir(dir)
+		t.Setenv("METRICS_PASSWORD_FILE", dir+"/yok")
+
+		_, err := Load("")
+		if err == nil || !strings.Contains(err.Error(), "METRICS_PASSWORD_FILE okunamadı") {
+			t.Errorf("Load hatası = %v", err)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileEnv = "CONFIG_FILE"
+	fileEnvSuffix = "_FILE"
+	dotEnvFile    = ".env"
+	secretMask    = "******"
+)
+
+// Load yapılandırmayı varsayılanlar, yapılandırma dosyası, .env ve ortam
+// değişkenlerinden sırayla okur ve doğrular. path boşsa CONFIG_FILE değişkenine
+// bakılır; o da yoksa dosya aşaması atlanır. .env süreç ortamına yazılmaz,
+// yalnızca bir kez okunup ortam değişkenlerinin altında bir katman olarak kullanılır.
+// Boş değerler tanımsız kabul edilir. Gizli alanlar için <ANAHTAR>_FILE ile
+// değerin okunacağı dosya (Docker/Kubernetes secret) verilebilir.
+// Okuma ve doğrulama hataları birlikte döner.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	var errs []error
+
+	dotEnv, err := godotenv.Read(dotEnvFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, fmt.Errorf("%s okunamadı: %w", dotEnvFile, err))
+	}
+	lookup := func(key string) (string, bool) {
+		if v, ok := os.LookupEnv(key); ok && v != "" {
+			return v, true
+		}
+		v, ok := dotEnv[key]
+		return v, ok && v != ""
+	}
+
+	if path == "" {
+		path, _ = lookup(configFileEnv)
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, applyEnv(reflect.ValueOf(cfg).Elem(), lookup)...)
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("yapılandırma geçersiz:\n%w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("yapılandırma dosyası okunamadı (%s): %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	case ".toml":
+		_, err = toml.Decode(string(data), cfg)
+	default:
+		return fmt.Errorf("desteklenmeyen yapılandırma dosyası uzantısı: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("yapılandırma dosyası çözümlenemedi (%s): %w", path, err)
+	}
+	return nil
+}
+
+func applyEnv(v reflect.Value, lookup func(string) (string, bool)) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			errs = append(errs, applyEnv(field, lookup)...)
+			continue
+		}
+
+		key := t.Field(i).Tag.Get("env")
+		if key == "" {
+			continue
+		}
+		raw, ok := lookup(key)
+		if t.Field(i).Tag.Get("secret") != "" {
+			if path, hasFile := lookup(key + fileEnvSuffix); hasFile {
+				if ok {
+					errs = append(errs, fmt.Errorf("%s ve %s%s birlikte tanımlanamaz", key, key, fileEnvSuffix))
+					continue
+				}
+				data, err := os.ReadFile(path)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s%s okunamadı: %w", key, fileEnvSuffix, err))
+					continue
+				}
+				raw, ok = strings.TrimRight(string(data), "\r\n"), true
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(raw)
+		case reflect.Int:
+			n, err := strconv.Atoi(strings.TrimSpace(raw))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s tam sayı olmalı: %q", key, raw))
+				continue
+			}
+			field.SetInt(int64(n))
+		case reflect.Bool:
+			b, err := strconv.ParseBool(strings.TrimSpace(raw))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s true/false olmalı: %q", key, raw))
+				continue
+			}
+			field.SetBool(b)
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			var items []string
+			for _, item := range strings.Split(raw, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+		}
+	}
+	return errs
+}
+
+// Masked gizli (secret etiketli) alanları maskelenmiş bir kopya döner; config:show
+// çıktısında ve loglarda kullanılır. secret:"url" alanlarında yalnızca şifre gizlenir.
+func (c *Config) Masked() *Config {
+	masked := *c
+	masked.Database.Replica.URLs = append([]string(nil), c.Database.Replica.URLs...)
+	masked.Database.Replica.Nodes = append([]ReplicaNode(nil), c.Database.Replica.Nodes...)
+	maskSecrets(reflect.ValueOf(&masked).Elem())
+	return &masked
+}
+
+func maskSecrets(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			maskSecrets(field)
+			continue
+		}
+		secret := t.Field(i).Tag.Get("secret")
+		switch {
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < field.Len(); j++ {
+				maskSecrets(field.Index(j))
+			}
+		case secret == "":
+		case field.Kind() == reflect.String:
+			maskValue(field, secret)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+			for j := 0; j < field.Len(); j++ {
+				maskValue(field.Index(j), secret)
+			}
+		}
+	}
+}
+
+func maskValue(field reflect.Value, secret string) {
+	if field.String() == "" {
+		return
+	}
+	if secret == "url" {
+		if u, err := url.Parse(field.String()); err == nil {
+			field.SetString(u.Redacted())
+			return
+		}
+	}
+	field.SetString(secretMask)
+}
+
+// YAML etkin yapılandırmayı gizli alanlar maskelenmiş olarak YAML'a çevirir.
+func (c *Config) YAML() (string, error) {
+	out, err := yaml.Marshal(c.Masked())
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
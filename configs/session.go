@@ -5,33 +5,17 @@ import (
 	"time"
 
 	"zatrano/models"
-	"zatrano/pkg/env"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
 	"zatrano/pkg/sessions"
 
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
-var Session *session.Store
+func NewSessionStore(cfg *Config) *session.Store {
+	sessionExpirationHours := cfg.Session.ExpirationHours
 
-func InitSession() {
-	Session = createSessionStore()
-	sessions.InitializeSessionStore(Session)
-	logs.SLog.Info("Oturum (session) sistemi başlatıldı ve utils içinde kayıt edildi.")
-}
-
-func SetupSession() *session.Store {
-	if Session == nil {
-		logs.SLog.Warn("Session store isteniyor ancak henüz başlatılmamış, şimdi başlatılıyor.")
-		InitSession()
-	}
-	return Session
-}
-
-func createSessionStore() *session.Store {
-	sessionExpirationHours := env.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)
-
-	cookieSecure := env.IsProduction()
+	cookieSecure := cfg.IsProduction()
 
 	store := session.New(session.Config{
 		CookieHTTPOnly: false,
@@ -41,7 +25,13 @@ func createSessionStore() *session.Store {
 		CookieSameSite: "Lax",
 	})
 
-	logs.SLog.Info("Cookie tabanlı session sistemi %d saatlik süreyle yapılandırıldı.", sessionExpirationHours)
+	// Varsayılan bellek deposu oturumları sayamadığı için sarılır; aktif
+	// oturum metriği buradan okunur.
+	tracked := sessions.NewTrackedStorage(store.Storage)
+	store.Storage = tracked
+	metrics.SetActiveSessionsSource(tracked.Count)
+
+	logs.SLog.Infof("Cookie tabanlı session sistemi %d saatlik süreyle yapılandırıldı.", sessionExpirationHours)
 
 	registerGobTypes()
 
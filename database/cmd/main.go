@@ -2,23 +2,36 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"os"
 
 	"zatrano/configs"
 	"zatrano/database"
 	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
 )
 
 func main() {
-	logs.InitLogger()
-	defer logs.SyncLogger()
+	configFile := flag.String("config", "", "YAML/TOML yapılandırma dosyası (varsayılan: CONFIG_FILE)")
 	migrateFlag := flag.Bool("migrate", false, "Veritabanı başlatma işlemini çalıştır (migrasyonları içerir)")
 	seedFlag := flag.Bool("seed", false, "Veritabanı başlatma işlemini çalıştır (seederları içerir)")
 	flag.Parse()
 
-	configs.InitDB()
-	defer configs.CloseDB()
+	cfg, err := configs.Load(*configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	logs.InitLogger(cfg.App.Env, cfg.Log.Level)
+	defer logs.SyncLogger()
 
-	db := configs.GetDB()
+	db, err := configs.OpenDB(cfg)
+	if err != nil {
+		logs.Log.Fatal("Veritabanına bağlanılamadı", zap.Error(err))
+	}
+	defer configs.CloseDB(db)
 
 	logs.SLog.Info("Veritabanı başlatma işlemi çalıştırılıyor...")
 	database.Initialize(db, *migrateFlag, *seedFlag)
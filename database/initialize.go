@@ -1,10 +1,14 @@
 package database
 
 import (
+	"context"
+
 	"zatrano/database/migrations"
 	"zatrano/database/seeders"
 	"zatrano/models"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/turkishsearch"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -16,7 +20,7 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 		return
 	}
 
-	tx := db.Begin()
+	tx := db.WithContext(models.WithSystemActor(context.Background())).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -61,6 +65,19 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 }
 
 func RunMigrationsInOrder(db *gorm.DB) error {
+	logs.SLog.Info(" -> PostgreSQL eklentileri kontrol ediliyor...")
+	if err := migrations.EnableExtensions(db); err != nil {
+		logs.Log.Error("PostgreSQL eklentileri oluşturulamadı", zap.Error(err))
+		return err
+	}
+
+	logs.SLog.Info(" -> AuditLog migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateAuditLogsTable(db); err != nil {
+		logs.Log.Error("AuditLogs tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	logs.SLog.Info(" -> AuditLog migrasyonları tamamlandı.")
+
 	logs.SLog.Info(" -> User migrasyonları çalıştırılıyor...")
 	if err := migrations.MigrateUsersTable(db); err != nil {
 		logs.Log.Error("Users tablosu migrasyonu başarısız oldu", zap.Error(err))
@@ -68,10 +85,80 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	}
 	logs.SLog.Info(" -> User migrasyonları tamamlandı.")
 
+	logs.SLog.Info(" -> Sürüm geçmişi migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateHistoryTables(db, models.User{}); err != nil {
+		logs.Log.Error("Sürüm geçmişi tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	logs.SLog.Info(" -> Sürüm geçmişi migrasyonları tamamlandı.")
+
+	logs.SLog.Info(" -> Arama index'leri oluşturuluyor...")
+	if err := migrations.MigrateSearchIndexes(db, models.User{}); err != nil {
+		logs.Log.Error("Arama index'leri oluşturulamadı", zap.Error(err))
+		return err
+	}
+	logs.SLog.Info(" -> Arama index'leri tamamlandı.")
+
+	logs.SLog.Info(" -> Türkçe sıralama collation'ları uygulanıyor...")
+	if err := migrations.MigrateCollations(db, models.User{}); err != nil {
+		logs.Log.Error("Collation migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	logs.SLog.Info(" -> Collation migrasyonları tamamlandı.")
+
 	logs.SLog.Info("Tüm migrasyonlar başarıyla çalıştırıldı.")
 	return nil
 }
 
+func PendingMigrations(db *gorm.DB) ([]string, error) {
+	var pending []string
+	migrator := db.Migrator()
+
+	for _, model := range migratedModels() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			pending = append(pending, table)
+			continue
+		}
+		if historied, ok := model.(models.Historied); ok {
+			if historyTable := historied.HistoryTable(); historyTable != "" && !migrator.HasTable(historyTable) {
+				pending = append(pending, historyTable)
+			}
+		}
+
+		if provider, ok := model.(queryparams.ListSpecProvider); ok {
+			for _, column := range migrations.SearchIndexColumns(provider.ListSpec()) {
+				if name := turkishsearch.IndexName(table, column); !migrator.HasIndex(model, name) {
+					pending = append(pending, name)
+				}
+			}
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, table+"."+field.DBName)
+			}
+		}
+	}
+
+	return pending, nil
+}
+
+func migratedModels() []interface{} {
+	return []interface{}{
+		&models.User{},
+		&models.AuditLog{},
+	}
+}
+
 func CheckAndRunSeeders(db *gorm.DB) error {
 	systemUser := seeders.GetSystemUserConfig()
 	var existingUser models.User
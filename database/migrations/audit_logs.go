@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+func MigrateAuditLogsTable(db *gorm.DB) error {
+	logs.SLog.Info("AuditLog tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.AuditLog{}); err != nil {
+		return errors.New("AuditLog tablosu migrate edilemedi: " + err.Error())
+	}
+	logs.SLog.Info("AuditLog tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/turkishlocale"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// MigrateCollations modellerin ListSpec'te sıralanabilir olan metin
+// kolonlarına Türkçe ICU collation'ını uygular; böylece "Çağla" "Cem"den
+// sonra, "Zeynep"ten önce sıralanır. PostgreSQL ICU desteği olmadan
+// derlenmişse uyarı loglanır ve varsayılan collation ile devam edilir.
+func MigrateCollations(db *gorm.DB, models ...queryparams.ListSpecProvider) error {
+	var available int64
+	err := db.Raw("SELECT count(*) FROM pg_collation WHERE collname = ?", turkishlocale.PostgresCollation).Scan(&available).Error
+	if err != nil {
+		return errors.New("collation kontrol edilemedi: " + err.Error())
+	}
+	if available == 0 {
+		logs.SLog.Warnf("%s collation'ı bulunamadı (PostgreSQL ICU desteği yok), sıralama varsayılan collation ile yapılacak.", turkishlocale.PostgresCollation)
+		return nil
+	}
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+
+		for _, column := range model.ListSpec().Sorts {
+			field := stmt.Schema.LookUpField(column)
+			if field == nil || field.DataType != schema.String {
+				continue
+			}
+			if err := applyCollation(db, table, column); err != nil {
+				return errors.New(table + "." + column + " collation'ı uygulanamadı: " + err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func applyCollation(db *gorm.DB, table, column string) error {
+	var current struct {
+		Type      string
+		Collation string
+	}
+	err := db.Raw(`SELECT format_type(a.atttypid, a.atttypmod) AS type, COALESCE(c.collname, '') AS collation
+		FROM pg_attribute a LEFT JOIN pg_collation c ON c.oid = a.attcollation
+		WHERE a.attrelid = to_regclass(?) AND a.attname = ? AND NOT a.attisdropped`, table, column).
+		Scan(&current).Error
+	if err != nil {
+		return err
+	}
+	if current.Type == "" || current.Collation == turkishlocale.PostgresCollation {
+		return nil
+	}
+
+	query := fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE %s COLLATE %s`,
+		db.Statement.Quote(table), db.Statement.Quote(column), current.Type, db.Statement.Quote(turkishlocale.PostgresCollation))
+	if err := db.Exec(query).Error; err != nil {
+		return err
+	}
+	logs.SLog.Infof("%s.%s kolonuna %s collation'ı uygulandı.", table, column, turkishlocale.PostgresCollation)
+	return nil
+}
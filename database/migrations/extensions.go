@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+// requiredExtensions listeleme aramalarının trigram index'leri ve benzerlik
+// sıralaması (pkg/turkishsearch) için ihtiyaç duyduğu PostgreSQL eklentileridir.
+var requiredExtensions = []string{"pg_trgm"}
+
+func EnableExtensions(db *gorm.DB) error {
+	for _, extension := range requiredExtensions {
+		if err := db.Exec("CREATE EXTENSION IF NOT EXISTS " + extension).Error; err != nil {
+			return errors.New(extension + " eklentisi oluşturulamadı: " + err.Error())
+		}
+		logs.SLog.Infof("%s eklentisi hazır.", extension)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+// MigrateHistoryTables sürüm geçmişi tutan modellerin *_versions tablolarını
+// oluşturur. Tüm geçmiş tabloları aynı RecordVersion yapısını kullandığından
+// index adları tablo adından türetilir.
+func MigrateHistoryTables(db *gorm.DB, historied ...models.Historied) error {
+	for _, model := range historied {
+		table := model.HistoryTable()
+		if table == "" {
+			continue
+		}
+
+		logs.SLog.Infof("%s tablosu migrate ediliyor...", table)
+		if err := db.Table(table).AutoMigrate(&models.RecordVersion{}); err != nil {
+			return errors.New(table + " tablosu migrate edilemedi: " + err.Error())
+		}
+		indexQuery := `CREATE INDEX IF NOT EXISTS idx_` + table + `_record ON ` + table + ` (record_id, version)`
+		if err := db.Exec(indexQuery).Error; err != nil {
+			return errors.New(table + " index'i oluşturulamadı: " + err.Error())
+		}
+		logs.SLog.Infof("%s tablosu migrate işlemi tamamlandı.", table)
+	}
+	return nil
+}
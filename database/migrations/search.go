@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/turkishsearch"
+
+	"gorm.io/gorm"
+)
+
+// MigrateSearchIndexes arama fonksiyonunu (tr_fold) oluşturur ve verilen
+// modellerin arama kolonları ile like filtre kolonları için trigram
+// index'lerini ekler. Tablolar bu adımdan önce oluşturulmuş olmalıdır.
+func MigrateSearchIndexes(db *gorm.DB, models ...queryparams.ListSpecProvider) error {
+	if err := db.Exec(turkishsearch.CreateFunctionSQL()).Error; err != nil {
+		return errors.New(turkishsearch.FunctionName + " fonksiyonu oluşturulamadı: " + err.Error())
+	}
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		table := stmt.Schema.Table
+
+		for _, column := range SearchIndexColumns(model.ListSpec()) {
+			if err := db.Exec(turkishsearch.IndexSQL(table, column)).Error; err != nil {
+				return errors.New(table + "." + column + " arama index'i oluşturulamadı: " + err.Error())
+			}
+		}
+		logs.SLog.Infof("%s tablosu arama index'leri hazır.", table)
+	}
+	return nil
+}
+
+// SearchIndexColumns spec'te tr_fold ile aranan kolonları tekrarsız döner.
+func SearchIndexColumns(spec queryparams.ListSpec) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(column string) {
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	for _, column := range spec.SearchColumns {
+		add(column)
+	}
+	for _, filter := range spec.Filters {
+		if filter.Type == queryparams.FilterLike {
+			add(filter.Column)
+		}
+	}
+	return columns
+}
@@ -30,6 +30,16 @@ func MigrateUsersTable(db *gorm.DB) error {
 	}
 	logs.SLog.Info("user_type enum başarıyla oluşturuldu.")
 
+	// Hesap adı yalnızca silinmemiş kullanıcılar arasında tekildir; eski tam
+	// tablo unique kısıtı varsa kaldırılır, yerine kısmi index oluşturulur.
+	if db.Migrator().HasTable(&models.User{}) {
+		for _, name := range []string{"uni_users_account", "users_account_key"} {
+			if _, err := rawDB.Exec(`ALTER TABLE users DROP CONSTRAINT IF EXISTS ` + name); err != nil {
+				return errors.New("users.account unique kısıtı kaldırılamadı: " + err.Error())
+			}
+		}
+	}
+
 	logs.SLog.Info("User tablosu migrate ediliyor...")
 	if err := db.AutoMigrate(&models.User{}); err != nil {
 		return errors.New("User tablosu migrate edilemedi: " + err.Error())
@@ -3,9 +3,12 @@ package handlers
 import (
 	"net/http"
 	"zatrano/models"
+	"zatrano/pkg/constants"
 	"zatrano/pkg/customerrors"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
 	"zatrano/pkg/renderer"
 	"zatrano/pkg/sessions"
 	"zatrano/services"
@@ -15,21 +18,24 @@ import (
 )
 
 type AuthHandler struct {
-	service services.IAuthService
+	service      services.IAuthService
+	secureCookie bool
 }
 
-func NewAuthHandler() *AuthHandler {
-	return &AuthHandler{service: services.NewAuthService()}
+func NewAuthHandler(service services.IAuthService, secureCookie bool) *AuthHandler {
+	return &AuthHandler{service: service, secureCookie: secureCookie}
 }
 
 func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	mapData := fiber.Map{
-		"Title": "Giriş",
+		"Title": l.T("auth.login.title"),
 	}
 	return renderer.Render(c, "auth/login", "layouts/auth", mapData, http.StatusOK)
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	var request struct {
 		Account  string `form:"account"`
 		Password string `form:"password"`
@@ -37,51 +43,62 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 
 	if err := c.BodyParser(&request); err != nil {
 		logs.SLog.Warnf("Login isteği ayrıştırılamadı: %v", err)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen hesap adı ve şifre alanlarını doldurun.")
+		flashmessages.Error(c, l.T("auth.login.required"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	if request.Account == "" || request.Password == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen hesap adı ve şifre alanlarını doldurun.")
+		flashmessages.KeepInput(c)
+		flashmessages.Error(c, l.T("auth.login.required"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	user, err := h.service.Authenticate(request.Account, request.Password)
+	user, err := h.service.Authenticate(c.UserContext(), request.Account, request.Password)
 	if err != nil {
 		var errMsg string
 		switch err {
 		case customerrors.ErrInvalidCredentials:
-			errMsg = "Kullanıcı adı veya şifre hatalı."
+			errMsg = l.T("auth.login.invalid_credentials")
 		case customerrors.ErrUserInactive:
-			errMsg = "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin."
+			errMsg = l.T("auth.login.inactive")
 		default:
-			errMsg = "Giriş işlemi sırasında bir sorun oluştu. Lütfen tekrar deneyin."
+			errMsg = l.T("auth.login.failed")
 			logs.Log.Error("Kimlik doğrulama servisinde beklenmeyen hata",
 				zap.String("account", request.Account),
 				zap.Error(err),
 			)
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		flashmessages.KeepInput(c)
+		flashmessages.Error(c, errMsg)
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	sess, sessionErr := sessions.SessionStart(c)
 	if sessionErr != nil {
 		logs.Log.Error("Oturum başlatılamadı (Login)", zap.Uint("user_id", user.ID), zap.String("account", user.Account), zap.Error(sessionErr))
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum başlatılamadı. Lütfen tekrar deneyin.")
+		flashmessages.Error(c, l.T("auth.session.start_failed"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	sess.Set("user_id", user.ID)
-	sess.Set("user_type", string(user.Type))
+	sess.Set("user_type", user.Type)
 	sess.Set("user_status", user.Status)
 	sess.Set("user_name", user.Name)
 
 	if saveErr := sess.Save(); saveErr != nil {
 		logs.Log.Error("Oturum kaydedilemedi (Login)", zap.Uint("user_id", user.ID), zap.String("account", user.Account), zap.Error(saveErr))
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum bilgileri kaydedilemedi.")
+		flashmessages.Error(c, l.T("auth.session.save_failed"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
+	metrics.RecordSessionCreated()
+
+	// Kayıtlı dil tercihi giriş sayfasında dil seçiciyle seçilen dilden
+	// önceliklidir. Cookie silinmezse ApplyUser kayıtlı dili hiç uygulamaz.
+	if user.Locale != "" {
+		i18n.ClearCookie(c, h.secureCookie)
+		i18n.SetPreferred(c, user.Locale)
+		l = i18n.From(c)
+	}
 
 	var redirectURL string
 	switch user.Type {
@@ -92,22 +109,23 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	default:
 		logs.Log.Error("Geçersiz kullanıcı tipi (Login sonrası yönlendirme)", zap.Uint("user_id", user.ID), zap.String("account", user.Account), zap.String("type", string(user.Type)))
 		_ = sess.Destroy()
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Hesabınız için tanımlanmış bir rol bulunamadı.")
+		flashmessages.Error(c, l.T("auth.login.no_role"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla giriş yapıldı.")
+	flashmessages.Success(c, l.T("auth.login.success"))
 	return c.Redirect(redirectURL, fiber.StatusFound)
 }
 
 func (h *AuthHandler) Profile(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	userID, ok := c.Locals("userID").(uint)
 	if !ok {
 		logs.SLog.Debug("Profil: UserID locals'ta bulunamadı, session kontrol ediliyor.")
 		sess, sessionErr := sessions.SessionStart(c)
 		if sessionErr != nil {
 			logs.Log.Error("Profil: Oturum başlatılamadı (locals'ta ID yok)", zap.Error(sessionErr))
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
+			flashmessages.Error(c, l.T("auth.session.error"))
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		}
 		userIDValue := sess.Get("user_id")
@@ -127,56 +145,111 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 		if !ok {
 			logs.Log.Warn("Profil: Session'da geçersiz veya eksik user_id", zap.Any("value", userIDValue))
 			_ = sess.Destroy()
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+			flashmessages.Error(c, l.T("auth.session.invalid"))
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		}
 		logs.SLog.Debugf("Profil: UserID session'dan alındı: %d", userID)
 	}
 
-	user, err := h.service.GetUserProfile(userID)
+	user, err := h.service.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
 		var errMsg string
 		if err == customerrors.ErrUserNotFound {
-			errMsg = "Profil bilgileri bulunamadı, lütfen tekrar giriş yapın."
+			errMsg = l.T("auth.profile.not_found")
 			logs.Log.Warn("Profil: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			sess, _ := sessions.SessionStart(c)
 			if sess != nil {
 				_ = sess.Destroy()
 			}
 		} else {
-			errMsg = "Profil bilgileri alınırken bir hata oluştu."
+			errMsg = l.T("auth.profile.failed")
 			logs.Log.Error("Profil: Kullanıcı profili alınırken hata", zap.Uint("user_id", userID), zap.Error(err))
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		flashmessages.Error(c, errMsg)
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
 	mapData := fiber.Map{
-		"Title": "Profilim",
-		"User":  user,
+		"Title":             l.T("auth.profile.title"),
+		"User":              user,
+		"MinPasswordLength": constants.MinPasswordLength,
+		"Timezones":         i18n.Timezones,
+		"DefaultTimezone":   i18n.Default().Location().String(),
 	}
 	return renderer.Render(c, "auth/profile", "layouts/auth", mapData, http.StatusOK)
 }
 
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	sess, err := sessions.SessionStart(c)
 	if err != nil {
 		logs.Log.Warn("Çıkış: Oturum başlatılamadı (muhtemelen zaten yok)", zap.Error(err))
 	}
 
-	flashMsg := "Başarıyla çıkış yapıldı."
 	if sess != nil {
 		if destroyErr := sess.Destroy(); destroyErr != nil {
 			logs.Log.Error("Çıkış: Oturum yok edilemedi", zap.Error(destroyErr))
-			flashMsg = "Çıkış yapıldı (ancak oturum temizlenirken bir sorun oluştu)."
+			flashmessages.Warning(c, l.T("auth.logout.partial"))
+			return c.Redirect("/auth/login", fiber.StatusFound)
 		}
+		metrics.RecordSessionDestroyed()
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, flashMsg)
+	flashmessages.Success(c, l.T("auth.logout.success"))
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
 
+// UpdatePreferences profil sayfasındaki dil ve saat dilimi tercihlerini
+// kaydeder. Kayıtlı dil dil seçicinin cookie'sini ezmediği için cookie de
+// seçilen dile göre güncellenir; "otomatik" seçilirse silinir.
+func (h *AuthHandler) UpdatePreferences(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		logs.Log.Warn("Tercih Güncelleme: Locals'ta geçersiz veya eksik user_id", zap.Any("value", c.Locals("userID")))
+		flashmessages.Error(c, l.T("auth.session.invalid"))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var request struct {
+		Locale   string `form:"locale"`
+		Timezone string `form:"timezone"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		logs.SLog.Warnf("Tercih güncelleme isteği ayrıştırılamadı: %v", err)
+		flashmessages.Error(c, l.T("auth.preferences.failed"))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	err := h.service.UpdatePreferences(c.UserContext(), userID, request.Locale, request.Timezone)
+	if err != nil {
+		var errMsg string
+		switch err {
+		case customerrors.ErrInvalidLocale, customerrors.ErrInvalidTimezone:
+			errMsg = l.Error(err)
+		default:
+			errMsg = l.T("auth.preferences.failed")
+		}
+		flashmessages.Error(c, errMsg)
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	if request.Locale != "" {
+		i18n.SetCookie(c, request.Locale, h.secureCookie)
+	} else {
+		i18n.ClearCookie(c, h.secureCookie)
+	}
+	if request.Timezone != "" {
+		i18n.SetTimezone(c, request.Timezone)
+	}
+
+	// Başarı mesajı yeni seçilen dilde gösterilir.
+	flashmessages.Success(c, i18n.From(c).T("auth.preferences.updated"))
+	return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+}
+
 func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	userID, ok := c.Locals("userID").(uint)
 	if !ok {
 		logs.Log.Warn("Parola Güncelleme: Locals'ta geçersiz veya eksik user_id", zap.Any("value", c.Locals("userID")))
@@ -184,7 +257,7 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		if sess != nil {
 			_ = sess.Destroy()
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		flashmessages.Error(c, l.T("auth.session.invalid"))
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
@@ -196,38 +269,40 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 
 	if err := c.BodyParser(&request); err != nil {
 		logs.SLog.Warnf("Parola güncelleme isteği ayrıştırılamadı: %v", err)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		flashmessages.Error(c, l.T("auth.password.required"))
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 
 	if request.CurrentPassword == "" || request.NewPassword == "" || request.ConfirmPassword == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		flashmessages.Error(c, l.T("auth.password.required"))
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 	if request.NewPassword != request.ConfirmPassword {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yeni şifreler uyuşmuyor.")
+		flashmessages.SetFieldError(c, "confirm_password", l.T("auth.password.mismatch"))
+		flashmessages.Error(c, l.T("auth.password.mismatch"))
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 
-	err := h.service.UpdatePassword(userID, request.CurrentPassword, request.NewPassword)
+	err := h.service.UpdatePassword(c.UserContext(), userID, request.CurrentPassword, request.NewPassword)
 	if err != nil {
 		var errMsg string
-		flashKey := flashmessages.FlashErrorKey
 		redirectTarget := "/auth/profile"
 		logoutUser := false
 
 		switch err {
 		case customerrors.ErrCurrentPasswordIncorrect:
-			errMsg = "Mevcut şifreniz hatalı."
+			errMsg = l.T("auth.password.current_incorrect")
+			flashmessages.SetFieldError(c, "current_password", errMsg)
 		case customerrors.ErrPasswordTooShort, customerrors.ErrPasswordSameAsOld:
-			errMsg = err.Error()
+			errMsg = l.Error(err)
+			flashmessages.SetFieldError(c, "new_password", errMsg)
 		case customerrors.ErrUserNotFound:
-			errMsg = "Kullanıcı bulunamadı, lütfen tekrar giriş yapın."
+			errMsg = l.T("auth.password.user_not_found")
 			logoutUser = true
 			redirectTarget = "/auth/login"
 			logs.Log.Warn("Parola Güncelleme: Kullanıcı bulunamadı (servis hatası)", zap.Uint("user_id", userID))
 		default:
-			errMsg = "Şifre güncellenirken bilinmeyen bir hata oluştu."
+			errMsg = l.T("auth.password.failed")
 			logs.Log.Error("Parola güncelleme servisinde beklenmeyen hata", zap.Uint("user_id", userID), zap.Error(err))
 		}
 
@@ -238,21 +313,22 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 			}
 		}
 
-		_ = flashmessages.SetFlashMessage(c, flashKey, errMsg)
+		flashmessages.Error(c, errMsg)
 		return c.Redirect(redirectTarget, fiber.StatusSeeOther)
 	}
 
-	flashMsg := "Şifre başarıyla güncellendi. Lütfen yeni şifrenizle tekrar giriş yapın."
 	sess, sessionErr := sessions.SessionStart(c)
 	if sess != nil {
 		if destroyErr := sess.Destroy(); destroyErr != nil {
 			logs.Log.Error("Parola güncellendi ancak oturum yok edilemedi", zap.Uint("user_id", userID), zap.Error(destroyErr))
-			flashMsg = "Şifre başarıyla güncellendi (ancak mevcut oturum sonlandırılamadı). Lütfen tekrar giriş yapın."
+			flashmessages.Warning(c, l.T("auth.password.updated_partial"))
+			return c.Redirect("/auth/login", fiber.StatusFound)
 		}
+		metrics.RecordSessionDestroyed()
 	} else if sessionErr != nil {
 		logs.Log.Warn("Parola güncellendi ancak oturum başlatılamadı/alınamadı (zaten yok olabilir)", zap.Uint("user_id", userID), zap.Error(sessionErr))
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, flashMsg)
+	flashmessages.Success(c, l.T("auth.password.updated"))
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
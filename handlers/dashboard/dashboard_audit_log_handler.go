@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"zatrano/models"
+	"zatrano/pkg/export"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type AuditLogHandler struct {
+	auditLogService services.IAuditLogService
+}
+
+func NewAuditLogHandler(auditLogService services.IAuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogService: auditLogService,
+	}
+}
+
+func auditLogExportColumns(loc *i18n.Localizer) []export.Column[models.AuditLog] {
+	return []export.Column[models.AuditLog]{
+		{Key: "id", Label: loc.T("audit.fields.id"), Value: func(l *models.AuditLog) interface{} { return l.ID }},
+		{Key: "created_at", Label: loc.T("audit.fields.created_at"), Value: func(l *models.AuditLog) interface{} { return loc.In(l.CreatedAt) }},
+		{Key: "actor_id", Label: loc.T("audit.fields.actor_id"), Value: func(l *models.AuditLog) interface{} {
+			if l.ActorID == nil {
+				return nil
+			}
+			return *l.ActorID
+		}},
+		{Key: "action", Label: loc.T("audit.fields.action"), Value: func(l *models.AuditLog) interface{} { return string(l.Action) }},
+		{Key: "table", Label: loc.T("audit.fields.table"), Value: func(l *models.AuditLog) interface{} { return l.Table }},
+		{Key: "record_id", Label: loc.T("audit.fields.record_id"), Value: func(l *models.AuditLog) interface{} { return l.RecordID }},
+		{Key: "changes", Label: loc.T("audit.fields.changes"), Value: func(l *models.AuditLog) interface{} {
+			data, err := json.Marshal(l.Changes)
+			if err != nil {
+				return ""
+			}
+			return string(data)
+		}},
+		{Key: "request_id", Label: loc.T("audit.fields.request_id"), Value: func(l *models.AuditLog) interface{} { return l.RequestID }},
+		{Key: "ip", Label: loc.T("audit.fields.ip"), Value: func(l *models.AuditLog) interface{} { return l.IP }},
+	}
+}
+
+// ListAuditLogs audit kayıtlarını listeler. Bir kaydın geçmişi için
+// filter[table]=users&filter[record_id]=5 biçiminde filtrelenir.
+func (h *AuditLogHandler) ListAuditLogs(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	params, err := queryparams.Parse(c.Queries(), models.AuditLog{}.ListSpec())
+	if err != nil {
+		logs.Log.Warn("Audit kayıtları: Geçersiz query parametreleri yok sayıldı.", zap.Error(err))
+	}
+	params.Location = l.Location()
+
+	paginatedResult, dbErr := h.auditLogService.GetAuditLogs(c.UserContext(), params)
+
+	renderData := fiber.Map{
+		"Title":  l.T("audit.title"),
+		"Result": paginatedResult,
+		"Params": params,
+
+		"ExportColumns": exportColumnsOf(auditLogExportColumns(l)),
+	}
+	statusCode := http.StatusOK
+
+	if dbErr != nil {
+		logs.Log.Error("Audit kayıtları DB Hatası", zap.Error(dbErr))
+		renderData[renderer.FlashErrorKeyView] = l.T("audit.list_failed")
+		renderData["Result"] = &queryparams.PaginatedResult{
+			Data: []models.AuditLog{},
+			Meta: queryparams.PaginationMeta{CurrentPage: params.Page, PerPage: params.PerPage},
+		}
+	}
+
+	return renderer.Render(c, "dashboard/audit_logs/list", "layouts/dashboard", renderData, statusCode)
+}
+
+// ExportAuditLogs listenin mevcut filtre ve sıralamasıyla tüm audit
+// kayıtlarını seçilen sütunlarla CSV veya XLSX olarak indirir.
+func (h *AuditLogHandler) ExportAuditLogs(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		flashmessages.Error(c, l.T("export.unsupported_format"))
+		return c.Redirect("/dashboard/audit-logs", fiber.StatusSeeOther)
+	}
+	params, err := queryparams.Parse(c.Queries(), models.AuditLog{}.ListSpec())
+	if err != nil {
+		logs.Log.Warn("Audit kayıtları dışa aktarma: Geçersiz query parametreleri yok sayıldı.", zap.Error(err))
+	}
+	params.Location = l.Location()
+	columns := export.SelectColumns(auditLogExportColumns(l), exportColumnKeys(c))
+
+	sheet := l.T("audit.title")
+	return streamExport(c, l.T("audit.export_filename"), format, func(ctx context.Context, w io.Writer) error {
+		return export.Write(ctx, w, format, sheet, columns, func(ctx context.Context, fn func(*models.AuditLog) error) error {
+			return h.auditLogService.StreamAuditLogs(ctx, params, fn)
+		})
+	})
+}
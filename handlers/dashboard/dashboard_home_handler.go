@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/logs"
 	"zatrano/pkg/renderer"
 	"zatrano/services"
@@ -14,21 +15,21 @@ type DashboardHomeHandler struct {
 	userService services.IUserService
 }
 
-func NewDashboardHomeHandler() *DashboardHomeHandler {
+func NewDashboardHomeHandler(userService services.IUserService) *DashboardHomeHandler {
 	return &DashboardHomeHandler{
-		userService: services.NewUserService(),
+		userService: userService,
 	}
 }
 
 func (h *DashboardHomeHandler) HomePage(c *fiber.Ctx) error {
-	userCount, userErr := h.userService.GetUserCount()
+	userCount, userErr := h.userService.GetUserCount(c.UserContext())
 	if userErr != nil {
 		logs.Log.Error("Anasayfa: Kullanıcı sayısı alınamadı", zap.Error(userErr))
 		userCount = 0
 	}
 
 	mapData := fiber.Map{
-		"Title":     "Dashboard",
+		"Title":     i18n.From(c).T("dashboard.title"),
 		"UserCount": userCount,
 	}
 	return renderer.Render(c, "dashboard/home/home", "layouts/dashboard", mapData, http.StatusOK)
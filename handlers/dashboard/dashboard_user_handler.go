@@ -1,15 +1,23 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"zatrano/models"
-	"zatrano/pkg/constants"
 	"zatrano/pkg/customerrors"
+	"zatrano/pkg/export"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/logs"
 	"zatrano/pkg/queryparams"
 	"zatrano/pkg/renderer"
+	"zatrano/repositories"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -20,51 +28,74 @@ type UserHandler struct {
 	userService services.IUserService
 }
 
-func NewUserHandler() *UserHandler {
+func NewUserHandler(userService services.IUserService) *UserHandler {
 	return &UserHandler{
-		userService: services.NewUserService(),
+		userService: userService,
 	}
 }
 
-func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
-	var params queryparams.ListParams
-	if err := c.QueryParser(&params); err != nil {
-		logs.Log.Warn("Kullanıcı listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
-		params = queryparams.ListParams{
-			Page: constants.DefaultPage, PerPage: constants.DefaultPerPage,
-			SortBy: constants.DefaultSortBy, OrderBy: constants.DefaultOrderBy,
-		}
+func userExportColumns(l *i18n.Localizer) []export.Column[models.User] {
+	return []export.Column[models.User]{
+		{Key: "id", Label: l.T("users.fields.id"), Value: func(u *models.User) interface{} { return u.ID }},
+		{Key: "name", Label: l.T("users.fields.name"), Value: func(u *models.User) interface{} { return u.Name }},
+		{Key: "account", Label: l.T("users.fields.account"), Value: func(u *models.User) interface{} { return u.Account }},
+		{Key: "type", Label: l.T("users.fields.type"), Value: func(u *models.User) interface{} { return string(u.Type) }},
+		{Key: "status", Label: l.T("users.fields.status"), Value: func(u *models.User) interface{} { return statusLabel(l, u.Status) }},
+		{Key: "created_at", Label: l.T("users.fields.created_at"), Value: func(u *models.User) interface{} { return l.In(u.CreatedAt) }},
+		{Key: "updated_at", Label: l.T("users.fields.updated_at"), Value: func(u *models.User) interface{} { return l.In(u.UpdatedAt) }},
+		{Key: "deleted_at", Label: l.T("users.fields.deleted_at"), Value: func(u *models.User) interface{} {
+			if !u.DeletedAt.Valid {
+				return nil
+			}
+			return l.In(u.DeletedAt.Time)
+		}},
 	}
+}
 
-	if params.Page <= 0 {
-		params.Page = constants.DefaultPage
-	}
-	if params.PerPage <= 0 {
-		params.PerPage = constants.DefaultPerPage
-	}
-	if params.PerPage > constants.MaxPerPage {
-		logs.Log.Warn("Sayfa başına istenen kayıt sayısı limiti aştı, varsayılana çekildi.",
-			zap.Int("requested", params.PerPage), zap.Int("max", constants.MaxPerPage), zap.Int("default", constants.DefaultPerPage))
-		params.PerPage = constants.DefaultPerPage
-	}
-	if params.SortBy == "" {
-		params.SortBy = constants.DefaultSortBy
+func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
+	return h.renderUserList(c, false)
+}
+
+// ListTrashedUsers aynı liste görünümünü "Silinenler" sekmesi için, yalnızca
+// silinmiş kullanıcılarla gösterir.
+func (h *UserHandler) ListTrashedUsers(c *fiber.Ctx) error {
+	return h.renderUserList(c, true)
+}
+
+func (h *UserHandler) renderUserList(c *fiber.Ctx, trash bool) error {
+	l := i18n.From(c)
+	params, err := queryparams.Parse(c.Queries(), models.User{}.ListSpec())
+	if err != nil {
+		logs.Log.Warn("Kullanıcı listesi: Geçersiz query parametreleri yok sayıldı.", zap.Error(err))
 	}
-	if params.OrderBy == "" {
-		params.OrderBy = constants.DefaultOrderBy
+	params.Location = l.Location()
+
+	title, listPath := l.T("users.title"), "/dashboard/users"
+	var (
+		paginatedResult *queryparams.PaginatedResult
+		dbErr           error
+	)
+	if trash {
+		title, listPath = l.T("users.trash_title"), "/dashboard/users/trash"
+		paginatedResult, dbErr = h.userService.ListTrashedUsers(c.UserContext(), params)
+	} else {
+		paginatedResult, dbErr = h.userService.GetAllUsers(c.UserContext(), params)
 	}
 
-	paginatedResult, dbErr := h.userService.GetAllUsers(params)
-
 	renderData := fiber.Map{
-		"Title":  "Kullanıcılar",
-		"Result": paginatedResult,
-		"Params": params,
+		"Title":    title,
+		"Result":   paginatedResult,
+		"Params":   params,
+		"Trash":    trash,
+		"ListPath": listPath,
+		"ReturnTo": c.OriginalURL(),
+
+		"ExportColumns": exportColumnsOf(userExportColumns(l)),
 	}
 	statusCode := http.StatusOK
 
 	if dbErr != nil {
-		dbErrMsg := "Kullanıcılar getirilirken bir hata oluştu."
+		dbErrMsg := l.T("users.list_failed")
 		logs.Log.Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
 		renderData[renderer.FlashErrorKeyView] = dbErrMsg
 		renderData["Result"] = &queryparams.PaginatedResult{
@@ -79,13 +110,15 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) ShowCreateUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	mapData := fiber.Map{
-		"Title": "Yeni Kullanıcı Ekle",
+		"Title": l.T("users.create.title"),
 	}
 	return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData)
 }
 
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	type Request struct {
 		Name     string `form:"name"`
 		Account  string `form:"account"`
@@ -98,8 +131,8 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		logs.SLog.Warnf("Kullanıcı oluşturma isteği ayrıştırılamadı: %v", err)
 		mapData := fiber.Map{
-			"Title":                    "Yeni Kullanıcı Ekle",
-			renderer.FlashErrorKeyView: "Geçersiz veri formatı veya eksik alanlar.",
+			"Title":                    l.T("users.create.title"),
+			renderer.FlashErrorKeyView: l.T("users.form.invalid"),
 			renderer.FormDataKey:       req,
 		}
 		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, http.StatusBadRequest)
@@ -107,8 +140,8 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 	if req.Name == "" || req.Account == "" || req.Password == "" || req.Type == "" {
 		mapData := fiber.Map{
-			"Title":                    "Yeni Kullanıcı Ekle",
-			renderer.FlashErrorKeyView: "Ad, Hesap Adı, Şifre ve Kullanıcı Tipi alanları zorunludur.",
+			"Title":                    l.T("users.create.title"),
+			renderer.FlashErrorKeyView: l.T("users.create.required"),
 			renderer.FormDataKey:       req,
 		}
 		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, http.StatusBadRequest)
@@ -126,8 +159,8 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 	if user.Type != models.Dashboard && user.Type != models.Panel {
 		mapData := fiber.Map{
-			"Title":                    "Yeni Kullanıcı Ekle",
-			renderer.FlashErrorKeyView: "Geçersiz kullanıcı tipi seçildi.",
+			"Title":                    l.T("users.create.title"),
+			renderer.FlashErrorKeyView: l.T("users.form.invalid_type"),
 			renderer.FormDataKey:       req,
 		}
 		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, http.StatusBadRequest)
@@ -135,60 +168,68 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 	if err := h.userService.CreateUser(c.UserContext(), &user); err != nil {
 		logs.Log.Error("Kullanıcı oluşturulamadı (Servis Hatası)", zap.String("account", req.Account), zap.Error(err))
-		errMsg := "Kullanıcı oluşturulamadı: " + err.Error()
+		errMsg := l.T("users.create.failed", l.Error(err))
 		statusCode := http.StatusInternalServerError
 		if errors.Is(err, customerrors.ErrPasswordRequired) || errors.Is(err, customerrors.ErrPasswordHashingFailed) {
 			statusCode = http.StatusBadRequest
 		}
 
 		mapData := fiber.Map{
-			"Title":                    "Yeni Kullanıcı Ekle",
+			"Title":                    l.T("users.create.title"),
 			renderer.FlashErrorKeyView: errMsg,
 			renderer.FormDataKey:       req,
 		}
 		return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", mapData, statusCode)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
+	flashmessages.Success(c, l.T("users.create.success"))
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
 
 func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
 		logs.Log.Warn("Kullanıcı güncelleme formu: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		flashmessages.Error(c, l.T("users.invalid_id"))
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
 
-	user, err := h.userService.GetUserByID(userID)
+	user, err := h.userService.GetUserByID(c.UserContext(), userID)
 	if err != nil {
 		var errMsg string
 		if errors.Is(err, customerrors.ErrUserServiceUserNotFound) {
 			logs.Log.Warn("Kullanıcı güncelleme formu: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
-			errMsg = "Düzenlenecek kullanıcı bulunamadı."
+			errMsg = l.T("users.update.not_found")
 		} else {
 			logs.Log.Error("Kullanıcı güncelleme formu: Kullanıcı alınamadı (Servis Hatası)", zap.Uint("user_id", userID), zap.Error(err))
-			errMsg = "Kullanıcı bilgileri alınırken hata oluştu."
+			errMsg = l.T("users.update.fetch_failed")
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		flashmessages.Error(c, errMsg)
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
+	history, historyErr := h.userService.GetUserHistory(c.UserContext(), userID)
+	if historyErr != nil {
+		logs.Log.Warn("Kullanıcı güncelleme formu: Sürüm geçmişi alınamadı", zap.Uint("user_id", userID), zap.Error(historyErr))
+	}
+
 	mapData := fiber.Map{
-		"Title": "Kullanıcı Düzenle",
-		"User":  user,
+		"Title":   l.T("users.update.title"),
+		"User":    user,
+		"History": history,
 	}
 
 	return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData)
 }
 
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
 		logs.Log.Warn("Kullanıcı güncelleme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		flashmessages.Error(c, l.T("users.invalid_id"))
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
@@ -200,15 +241,22 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		Password string `form:"password"`
 		Status   string `form:"status"`
 		Type     string `form:"type"`
+		Version  uint   `form:"version"`
+		// Base* formun açıldığı sürümdeki değerlerdir; çakışmada yalnızca
+		// arada başkası tarafından değiştirilen alanları göstermek için.
+		BaseName    string `form:"base_name"`
+		BaseAccount string `form:"base_account"`
+		BaseType    string `form:"base_type"`
+		BaseStatus  string `form:"base_status"`
 	}
 	var req Request
 
 	if err := c.BodyParser(&req); err != nil {
 		logs.Log.Warn("Kullanıcı güncelleme: Form verileri okunamadı", zap.Uint("user_id", userID), zap.Error(err))
-		user, _ := h.userService.GetUserByID(userID)
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Form verileri okunamadı veya eksik.",
+			"Title":                    l.T("users.update.title"),
+			renderer.FlashErrorKeyView: l.T("users.form.unreadable"),
 			renderer.FormDataKey:       req,
 			"User":                     user,
 		}
@@ -216,10 +264,10 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	}
 
 	if req.Name == "" || req.Account == "" || req.Type == "" {
-		user, _ := h.userService.GetUserByID(userID)
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Ad, Hesap Adı ve Kullanıcı Tipi alanları zorunludur.",
+			"Title":                    l.T("users.update.title"),
+			renderer.FlashErrorKeyView: l.T("users.update.required"),
 			renderer.FormDataKey:       req,
 			"User":                     user,
 		}
@@ -228,10 +276,24 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 
 	userType := models.UserType(req.Type)
 	if userType != models.Dashboard && userType != models.Panel {
-		user, _ := h.userService.GetUserByID(userID)
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
+		mapData := fiber.Map{
+			"Title":                    l.T("users.update.title"),
+			renderer.FlashErrorKeyView: l.T("users.form.invalid_type"),
+			renderer.FormDataKey:       req,
+			"User":                     user,
+		}
+		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, http.StatusBadRequest)
+	}
+
+	// Sürüm, formun hangi kayıt üzerinde açıldığını gösterir; olmadan
+	// yapılan bir kayıt arada yapılmış değişiklikleri fark etmeden ezer.
+	if req.Version == 0 {
+		logs.Log.Warn("Kullanıcı güncelleme: Formda sürüm bilgisi yok", zap.Uint("user_id", userID))
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Geçersiz kullanıcı tipi seçildi.",
+			"Title":                    l.T("users.update.title"),
+			renderer.FlashErrorKeyView: l.T("users.update.version_required"),
 			renderer.FormDataKey:       req,
 			"User":                     user,
 		}
@@ -246,27 +308,62 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		Status:  status,
 		Type:    userType,
 	}
+	userUpdateData.Version = req.Version
 	if req.Password != "" {
 		userUpdateData.Password = req.Password
 	}
 
 	if err := h.userService.UpdateUser(c.UserContext(), userID, userUpdateData); err != nil {
-		errMsg := "Kullanıcı güncellenemedi: " + err.Error()
+		errMsg := l.T("users.update.failed", l.Error(err))
 		statusCode := http.StatusInternalServerError
 
 		if errors.Is(err, customerrors.ErrUserServiceUserNotFound) {
 			logs.Log.Warn("Kullanıcı güncelleme: Kullanıcı bulunamadı (Servis hatası)", zap.Uint("user_id", userID))
-			errMsg = "Güncellenecek kullanıcı bulunamadı."
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+			errMsg = l.T("users.update.target_not_found")
+			flashmessages.Error(c, errMsg)
 			return c.Redirect(redirectPathOnSuccess, fiber.StatusSeeOther)
-		} else if errors.Is(err, customerrors.ErrPasswordUpdateFailed) || errors.Is(err, customerrors.ErrPasswordHashingFailed) {
+		} else if errors.Is(err, customerrors.ErrUserVersionConflict) {
+			// Replika gecikmeliyse eski sürümü dönüp çakışmayı tekrar
+			// üreteceği için güncel kayıt birincilden okunur.
+			current, getErr := h.userService.GetUserByID(repositories.ReadYourWrites(c.UserContext()), userID)
+			if getErr != nil {
+				flashmessages.Error(c, l.T("users.update.target_not_found"))
+				return c.Redirect(redirectPathOnSuccess, fiber.StatusSeeOther)
+			}
+			base := userUpdateData
+			if req.BaseAccount != "" {
+				base = &models.User{
+					Name:    req.BaseName,
+					Account: req.BaseAccount,
+					Type:    models.UserType(req.BaseType),
+					Status:  req.BaseStatus == "true",
+				}
+			}
+			conflicts := userConflicts(l, base, current, userUpdateData)
+
+			// Form girilen değerlerle tekrar gösterilir; sürüm ve taban
+			// değerler güncel kayda çekildiğinden kullanıcı farkları görüp
+			// bilerek tekrar kaydedebilir.
+			req.Version = current.Version
+			req.BaseName, req.BaseAccount = current.Name, current.Account
+			req.BaseType, req.BaseStatus = string(current.Type), strconv.FormatBool(current.Status)
+			mapData := fiber.Map{
+				"Title":                    l.T("users.update.title"),
+				renderer.FlashErrorKeyView: l.T("users.update.conflict"),
+				renderer.FormDataKey:       req,
+				"User":                     current,
+				"Conflicts":                conflicts,
+			}
+			return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, http.StatusConflict)
+		} else if errors.Is(err, customerrors.ErrPasswordUpdateFailed) || errors.Is(err, customerrors.ErrPasswordHashingFailed) ||
+			errors.Is(err, customerrors.ErrUserVersionRequired) {
 			statusCode = http.StatusBadRequest
 		}
 
 		logs.Log.Error("Kullanıcı güncelleme: Handler'da servis hatası yakalandı", zap.Uint("user_id", userID), zap.Error(err))
-		user, _ := h.userService.GetUserByID(userID)
+		user, _ := h.userService.GetUserByID(c.UserContext(), userID)
 		mapData := fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
+			"Title":                    l.T("users.update.title"),
 			renderer.FlashErrorKeyView: errMsg,
 			renderer.FormDataKey:       req,
 			"User":                     user,
@@ -274,15 +371,65 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", mapData, statusCode)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla güncellendi.")
+	flashmessages.Success(c, l.T("users.update.success"))
 	return c.Redirect(redirectPathOnSuccess, fiber.StatusFound)
 }
 
+// RevertUserVersion kullanıcıyı geçmişteki bir sürüme döndürür. Formdaki
+// version alanı, geri alma butonunun gösterildiği andaki güncel sürümdür.
+func (h *UserHandler) RevertUserVersion(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	id, err := c.ParamsInt("id")
+	versionID, versionErr := c.ParamsInt("versionId")
+	if err != nil || id <= 0 || versionErr != nil || versionID <= 0 {
+		logs.Log.Warn("Kullanıcı sürüme döndürme: Geçersiz ID parametresi",
+			zap.String("param", c.Params("id")),
+			zap.String("version_param", c.Params("versionId")),
+		)
+		flashmessages.Error(c, l.T("users.revert.invalid_id"))
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	editPath := "/dashboard/users/update/" + strconv.Itoa(id)
+
+	expectedVersion, err := strconv.ParseUint(c.FormValue("version"), 10, 64)
+	if err != nil || expectedVersion == 0 {
+		logs.Log.Warn("Kullanıcı sürüme döndürme: Formda geçerli sürüm bilgisi yok",
+			zap.Uint("user_id", userID),
+			zap.String("version", c.FormValue("version")),
+		)
+		flashmessages.Error(c, l.T("users.revert.version_required"))
+		return c.Redirect(editPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.RevertUserToVersion(c.UserContext(), userID, uint(versionID), uint(expectedVersion)); err != nil {
+		var errMsg string
+		switch {
+		case errors.Is(err, customerrors.ErrUserServiceUserNotFound):
+			flashmessages.Error(c, l.T("users.revert.user_not_found"))
+			return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+		case errors.Is(err, customerrors.ErrUserVersionNotFound):
+			errMsg = l.T("users.revert.version_not_found")
+		case errors.Is(err, customerrors.ErrUserVersionConflict):
+			errMsg = l.T("users.revert.conflict")
+		default:
+			logs.Log.Error("Kullanıcı sürüme döndürme: Servis hatası", zap.Uint("user_id", userID), zap.Int("version_id", versionID), zap.Error(err))
+			errMsg = l.T("users.revert.failed", l.Error(err))
+		}
+		flashmessages.Error(c, errMsg)
+		return c.Redirect(editPath, fiber.StatusSeeOther)
+	}
+
+	flashmessages.Success(c, l.T("users.revert.success"))
+	return c.Redirect(editPath, fiber.StatusFound)
+}
+
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
 		logs.Log.Warn("Kullanıcı silme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		flashmessages.Error(c, l.T("users.invalid_id"))
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
@@ -291,15 +438,198 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 		var errMsg string
 		if errors.Is(err, customerrors.ErrUserServiceUserNotFound) {
 			logs.Log.Warn("Kullanıcı silme: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
-			errMsg = "Silinecek kullanıcı bulunamadı."
+			errMsg = l.T("users.delete.not_found")
 		} else {
 			logs.Log.Error("Kullanıcı silme: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
-			errMsg = "Kullanıcı silinemedi: " + err.Error()
+			errMsg = l.T("users.delete.failed", l.Error(err))
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		flashmessages.Error(c, errMsg)
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla silindi.")
+	flashmessages.Success(c, l.T("users.delete.success"))
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
+
+func (h *UserHandler) RestoreUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	const trashPath = "/dashboard/users/trash"
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		logs.Log.Warn("Kullanıcı geri yükleme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		flashmessages.Error(c, l.T("users.invalid_id"))
+		return c.Redirect(trashPath, fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+
+	if err := h.userService.RestoreUser(c.UserContext(), userID); err != nil {
+		var errMsg string
+		switch {
+		case errors.Is(err, customerrors.ErrUserServiceUserNotFound):
+			errMsg = l.T("users.restore.not_found")
+		case errors.Is(err, customerrors.ErrUserRestoreConflict):
+			errMsg = l.T("users.restore.conflict")
+		default:
+			logs.Log.Error("Kullanıcı geri yükleme: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = l.T("users.restore.failed", l.Error(err))
+		}
+		flashmessages.Error(c, errMsg)
+		return c.Redirect(trashPath, fiber.StatusSeeOther)
+	}
+
+	flashmessages.Success(c, l.T("users.restore.success"))
+	return c.Redirect(trashPath, fiber.StatusFound)
+}
+
+func (h *UserHandler) ForceDeleteUser(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	const trashPath = "/dashboard/users/trash"
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		logs.Log.Warn("Kullanıcı kalıcı silme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		flashmessages.Error(c, l.T("users.invalid_id"))
+		return c.Redirect(trashPath, fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+
+	if err := h.userService.ForceDeleteUser(c.UserContext(), userID); err != nil {
+		var errMsg string
+		if errors.Is(err, customerrors.ErrUserServiceUserNotFound) {
+			errMsg = l.T("users.purge.not_found")
+		} else {
+			logs.Log.Error("Kullanıcı kalıcı silme: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = l.T("users.purge.failed", l.Error(err))
+		}
+		flashmessages.Error(c, errMsg)
+		return c.Redirect(trashPath, fiber.StatusSeeOther)
+	}
+
+	flashmessages.Success(c, l.T("users.purge.success"))
+	return c.Redirect(trashPath, fiber.StatusFound)
+}
+
+func (h *UserHandler) ExportUsers(c *fiber.Ctx) error {
+	return h.exportUserList(c, false)
+}
+
+func (h *UserHandler) ExportTrashedUsers(c *fiber.Ctx) error {
+	return h.exportUserList(c, true)
+}
+
+// exportUserList listenin mevcut filtre ve sıralamasıyla tüm kullanıcıları
+// seçilen sütunlarla CSV veya XLSX olarak indirir.
+func (h *UserHandler) exportUserList(c *fiber.Ctx, trash bool) error {
+	l := i18n.From(c)
+	name, sheet, listPath := l.T("users.export.filename"), l.T("users.title"), "/dashboard/users"
+	if trash {
+		name, sheet, listPath = l.T("users.export.trash_filename"), l.T("users.trash_title"), "/dashboard/users/trash"
+	}
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		flashmessages.Error(c, l.T("export.unsupported_format"))
+		return c.Redirect(listPath, fiber.StatusSeeOther)
+	}
+	params, err := queryparams.Parse(c.Queries(), models.User{}.ListSpec())
+	if err != nil {
+		logs.Log.Warn("Kullanıcı dışa aktarma: Geçersiz query parametreleri yok sayıldı.", zap.Error(err))
+	}
+	params.Location = l.Location()
+	columns := export.SelectColumns(userExportColumns(l), exportColumnKeys(c))
+
+	return streamExport(c, name, format, func(ctx context.Context, w io.Writer) error {
+		return export.Write(ctx, w, format, sheet, columns, func(ctx context.Context, fn func(*models.User) error) error {
+			return h.userService.StreamUsers(ctx, params, trash, fn)
+		})
+	})
+}
+
+// BulkUserAction listede seçilen kullanıcılara toplu işlem uygular ve
+// kullanıcıyı filtreleri korunmuş haliyle listeye geri gönderir. Başarısız
+// satırlar ID'leri ve nedenleriyle birlikte hata mesajında listelenir.
+func (h *UserHandler) BulkUserAction(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	returnTo := c.FormValue("return_to")
+	if !strings.HasPrefix(returnTo, "/dashboard/users") {
+		returnTo = "/dashboard/users"
+	}
+
+	var ids []uint
+	for _, raw := range c.Request().PostArgs().PeekMulti("ids") {
+		id, err := strconv.ParseUint(string(raw), 10, 64)
+		if err != nil || id == 0 {
+			logs.Log.Warn("Kullanıcı toplu işlem: Geçersiz ID yok sayıldı", zap.ByteString("id", raw))
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+
+	action := services.UserBulkAction(c.FormValue("action"))
+	result, err := h.userService.BulkUserAction(c.UserContext(), action, ids, models.UserType(c.FormValue("type")))
+	if err != nil {
+		var errMsg string
+		switch {
+		case errors.Is(err, customerrors.ErrUserBulkEmpty):
+			errMsg = l.T("users.bulk.empty")
+		case errors.Is(err, customerrors.ErrUserBulkInvalidAction):
+			errMsg = l.T("users.bulk.invalid_action")
+		case errors.Is(err, customerrors.ErrUserBulkInvalidType):
+			errMsg = l.T("users.bulk.invalid_type")
+		default:
+			logs.Log.Error("Kullanıcı toplu işlem: Servis hatası", zap.String("action", string(action)), zap.Error(err))
+			errMsg = l.T("users.bulk.failed", l.Error(err))
+		}
+		flashmessages.Error(c, errMsg)
+		return c.Redirect(returnTo, fiber.StatusSeeOther)
+	}
+
+	if len(result.Succeeded) > 0 {
+		flashmessages.Success(c, l.N("users.bulk.succeeded", len(result.Succeeded)))
+	}
+	if len(result.Failed) > 0 {
+		failedIDs := make([]uint, 0, len(result.Failed))
+		for id := range result.Failed {
+			failedIDs = append(failedIDs, id)
+		}
+		sort.Slice(failedIDs, func(i, j int) bool { return failedIDs[i] < failedIDs[j] })
+
+		reasons := make([]string, 0, len(failedIDs))
+		for _, id := range failedIDs {
+			reasons = append(reasons, fmt.Sprintf("#%d: %s", id, l.Error(result.Failed[id])))
+		}
+		flashmessages.Warning(c, l.N("users.bulk.partially_failed", len(failedIDs), strings.Join(reasons, "; ")))
+	}
+	return c.Redirect(returnTo, fiber.StatusSeeOther)
+}
+
+// fieldConflict sürüm çakışmasında form açıldıktan sonra başkası tarafından
+// değiştirilmiş bir alanı, formda girilen değerle birlikte gösterir.
+type fieldConflict struct {
+	Label     string
+	Submitted string
+	Current   string
+}
+
+// userConflicts formun açıldığı sürümden (base) bu yana güncel kayıtta
+// değişen alanları döner; kullanıcının kendi yaptığı değişiklikler çakışma
+// sayılmaz.
+func userConflicts(l *i18n.Localizer, base, current, submitted *models.User) []fieldConflict {
+	var conflicts []fieldConflict
+	add := func(label, baseValue, submittedValue, currentValue string) {
+		if baseValue != currentValue {
+			conflicts = append(conflicts, fieldConflict{Label: label, Submitted: submittedValue, Current: currentValue})
+		}
+	}
+	add(l.T("users.fields.name"), base.Name, submitted.Name, current.Name)
+	add(l.T("users.fields.account_name"), base.Account, submitted.Account, current.Account)
+	add(l.T("users.fields.type"), string(base.Type), string(submitted.Type), string(current.Type))
+	add(l.T("users.fields.status"), statusLabel(l, base.Status), statusLabel(l, submitted.Status), statusLabel(l, current.Status))
+	return conflicts
+}
+
+func statusLabel(l *i18n.Localizer, active bool) string {
+	if active {
+		return l.T("common.active")
+	}
+	return l.T("common.inactive")
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"zatrano/pkg/csvimport"
+	"zatrano/pkg/customerrors"
+	"zatrano/pkg/export"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/renderer"
+	"zatrano/pkg/sessions"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	userImportPath       = "/dashboard/users/import"
+	userImportPreviewURL = "/dashboard/users/import/preview"
+	userImportSessionKey = "user_import_token"
+	userImportMaxBytes   = 2 << 20
+	userImportMaxRows    = 5000
+	userImportMaxAge     = time.Hour
+	// userImportTimeout şifre hashleme ve parti kayıtları istek başına
+	// sorgu süresini aşabileceği için içe aktarmanın kendi süre sınırıdır.
+	userImportTimeout = 5 * time.Minute
+)
+
+// userImportFields başlık tahmini için etiketleri isteğin dilinde, takma
+// adları hem Türkçe hem İngilizce başlıkları tanıyacak şekilde tanımlar.
+func userImportFields(l *i18n.Localizer) []csvimport.Field {
+	return []csvimport.Field{
+		{Key: "name", Label: l.T("users.fields.name"), Required: true, Aliases: []string{"ad soyad", "ad", "isim", "adı soyadı", "name", "full name"}},
+		{Key: "account", Label: l.T("users.fields.account"), Required: true, Aliases: []string{"hesap", "hesap adı", "kullanıcı adı", "account", "username", "e-posta", "email"}},
+		{Key: "password", Label: l.T("users.fields.password"), Required: true, Aliases: []string{"şifre", "parola", "password"}},
+		{Key: "type", Label: l.T("users.fields.type"), Aliases: []string{"kullanıcı tipi", "tip", "tür", "type", "user type"}},
+		{Key: "status", Label: l.T("users.fields.status"), Aliases: []string{"durum", "aktif", "status", "active"}},
+	}
+}
+
+func userImportResultColumns(l *i18n.Localizer) []export.Column[services.UserImportResult] {
+	return []export.Column[services.UserImportResult]{
+		{Key: "line", Label: l.T("import.columns.line"), Value: func(r *services.UserImportResult) interface{} { return r.Row.Line }},
+		{Key: "name", Label: l.T("users.fields.name"), Value: func(r *services.UserImportResult) interface{} { return r.Row.Name }},
+		{Key: "account", Label: l.T("users.fields.account"), Value: func(r *services.UserImportResult) interface{} { return r.Row.Account }},
+		{Key: "type", Label: l.T("users.fields.type"), Value: func(r *services.UserImportResult) interface{} { return r.Row.Type }},
+		{Key: "status", Label: l.T("users.fields.status"), Value: func(r *services.UserImportResult) interface{} { return r.Row.Status }},
+		{Key: "result", Label: l.T("import.columns.result"), Value: func(r *services.UserImportResult) interface{} {
+			if r.Created {
+				return l.T("import.result.created")
+			}
+			return l.T("import.result.failed")
+		}},
+		{Key: "user_id", Label: l.T("import.columns.user_id"), Value: func(r *services.UserImportResult) interface{} {
+			if !r.Created {
+				return nil
+			}
+			return r.User.ID
+		}},
+		{Key: "errors", Label: l.T("import.columns.errors"), Value: func(r *services.UserImportResult) interface{} {
+			messages := make([]string, len(r.Errors))
+			for i, err := range r.Errors {
+				messages[i] = l.Error(err)
+			}
+			return strings.Join(messages, "; ")
+		}},
+	}
+}
+
+// mappedField içe aktarma sayfasındaki sütun eşleme seçimini taşır.
+type mappedField struct {
+	csvimport.Field
+	Index  int
+	Mapped bool
+}
+
+// UserImportHandler kullanıcıların CSV'den içe aktarılmasını üç adımda
+// yürütür: dosya yükleme, sütun eşleme ile önizleme ve içe aktarma. Yüklenen
+// dosya adımlar arasında geçici dizinde, anahtarı ise session'da tutulur.
+type UserImportHandler struct {
+	userService services.IUserService
+	store       *csvimport.Store
+}
+
+func NewUserImportHandler(userService services.IUserService) *UserImportHandler {
+	return &UserImportHandler{
+		userService: userService,
+		store:       csvimport.NewStore("zatrano-user-imports", userImportMaxAge),
+	}
+}
+
+// CleanupUploads süresi dolmuş yüklemeleri ve sonuç dosyalarını siler;
+// uygulama bunu periyodik olarak çalıştırır.
+func (h *UserImportHandler) CleanupUploads() {
+	h.store.Cleanup()
+}
+
+func (h *UserImportHandler) ShowImportUsers(c *fiber.Ctx) error {
+	return h.render(c, fiber.Map{"Step": "upload"}, http.StatusOK)
+}
+
+// UploadImportUsers dosyayı okunabilirliği için ayrıştırır, saklar ve
+// önizleme sayfasına yönlendirir.
+func (h *UserImportHandler) UploadImportUsers(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	uploadError := func(msg string) error {
+		return h.render(c, fiber.Map{"Step": "upload", renderer.FlashErrorKeyView: msg}, http.StatusBadRequest)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return uploadError(l.T("import.upload.no_file"))
+	}
+	if fileHeader.Size > userImportMaxBytes {
+		return uploadError(l.T("import.upload.too_large", userImportMaxBytes>>20))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logs.Log.Error("Kullanıcı içe aktarma: Yüklenen dosya açılamadı", zap.Error(err))
+		return uploadError(l.T("import.upload.unreadable"))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, userImportMaxBytes+1))
+	if err != nil || len(data) > userImportMaxBytes {
+		return uploadError(l.T("import.upload.unreadable"))
+	}
+	if _, err := csvimport.Read(bytes.NewReader(data), userImportMaxRows); err != nil {
+		logs.Log.Warn("Kullanıcı içe aktarma: Dosya ayrıştırılamadı", zap.String("filename", fileHeader.Filename), zap.Error(err))
+		return uploadError(csvReadError(l, err))
+	}
+
+	token, err := h.store.Save(data)
+	if err != nil {
+		logs.Log.Error("Kullanıcı içe aktarma: Dosya saklanamadı", zap.Error(err))
+		return uploadError(l.T("import.upload.save_failed"))
+	}
+	sess, err := sessions.SessionStart(c)
+	if err != nil {
+		return err
+	}
+	sess.Set(userImportSessionKey, token)
+	if err := sess.Save(); err != nil {
+		return err
+	}
+
+	logs.Log.Info("Kullanıcı içe aktarma dosyası yüklendi",
+		zap.String("filename", fileHeader.Filename),
+		zap.Int64("size", fileHeader.Size),
+	)
+	return c.Redirect(userImportPreviewURL, fiber.StatusSeeOther)
+}
+
+// PreviewImportUsers sütun eşlemesini (query'de yoksa başlıklardan tahmin
+// edilir) uygular ve satırları kaydetmeden doğrulayıp gösterir.
+func (h *UserImportHandler) PreviewImportUsers(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	table, token, err := h.loadUpload(c)
+	if err != nil {
+		return h.uploadMissing(c, err)
+	}
+
+	fields := userImportFields(l)
+	mapping := csvimport.GuessMapping(table.Header, fields)
+	if c.Query("mapped") != "" {
+		mapping = csvimport.ParseMapping(fields, len(table.Header), func(key string) string { return c.Query(key) })
+	}
+
+	data := fiber.Map{
+		"Step":   "preview",
+		"Token":  token,
+		"Header": table.Header,
+		"Fields": mappedFields(fields, mapping),
+	}
+	if missing := mapping.Missing(fields); len(missing) > 0 {
+		labels := make([]string, len(missing))
+		for i, field := range missing {
+			labels[i] = field.Label
+		}
+		data[renderer.FlashErrorKeyView] = l.T("import.preview.missing_fields", strings.Join(labels, ", "))
+		return h.render(c, data, http.StatusOK)
+	}
+
+	report, err := h.userService.PreviewUserImport(c.UserContext(), userImportRows(table, mapping))
+	if err != nil {
+		msg := l.T("import.preview.failed", l.Error(err))
+		if !errors.Is(err, customerrors.ErrUserImportEmpty) {
+			logs.Log.Error("Kullanıcı içe aktarma önizlemesi başarısız", zap.Error(err))
+		}
+		data[renderer.FlashErrorKeyView] = msg
+		return h.render(c, data, http.StatusOK)
+	}
+	data["Report"] = report
+	return h.render(c, data, http.StatusOK)
+}
+
+// RunImportUsers eşlemeyle satırları içe aktarır, sonuç dosyasını saklar ve
+// özet sayfasını gösterir. Yüklenen dosya bu adımdan sonra silinir.
+func (h *UserImportHandler) RunImportUsers(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	table, token, err := h.loadUpload(c)
+	if err != nil {
+		return h.uploadMissing(c, err)
+	}
+
+	fields := userImportFields(l)
+	mapping := csvimport.ParseMapping(fields, len(table.Header), func(key string) string { return c.FormValue(key) })
+	if len(mapping.Missing(fields)) > 0 {
+		flashmessages.Error(c, l.T("import.run.missing_mapping"))
+		return c.Redirect(userImportPreviewURL, fiber.StatusSeeOther)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), userImportTimeout)
+	defer cancel()
+
+	report, err := h.userService.ImportUsers(ctx, userImportRows(table, mapping))
+	if err != nil {
+		logs.Log.Error("Kullanıcı içe aktarma başarısız", zap.Error(err))
+		flashmessages.Error(c, l.T("import.run.failed", l.Error(err)))
+		return c.Redirect(userImportPreviewURL, fiber.StatusSeeOther)
+	}
+
+	err = h.store.SaveResult(token, func(w io.Writer) error {
+		return export.Write(ctx, w, export.CSV, "", userImportResultColumns(l), func(ctx context.Context, fn func(*services.UserImportResult) error) error {
+			for i := range report.Results {
+				if err := fn(&report.Results[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	data := fiber.Map{"Step": "result", "Report": report, "HasResultFile": err == nil}
+	if err != nil {
+		logs.Log.Error("Kullanıcı içe aktarma sonuç dosyası yazılamadı", zap.Error(err))
+		data[renderer.FlashErrorKeyView] = l.T("import.run.result_file_failed")
+	}
+	return h.render(c, data, http.StatusOK)
+}
+
+// DownloadImportResult son içe aktarmanın satır bazındaki sonuç dosyasını indirir.
+func (h *UserImportHandler) DownloadImportResult(c *fiber.Ctx) error {
+	l := i18n.From(c)
+	file, err := h.store.OpenResult(h.sessionToken(c))
+	if err != nil {
+		return h.uploadMissing(c, err)
+	}
+	c.Attachment(l.T("import.result_filename") + ".csv")
+	c.Set(fiber.HeaderContentType, export.CSV.ContentType())
+	return c.SendStream(file)
+}
+
+func (h *UserImportHandler) render(c *fiber.Ctx, data fiber.Map, status int) error {
+	l := i18n.From(c)
+	data["Title"] = l.T("import.title")
+	return renderer.Render(c, "dashboard/users/import", "layouts/dashboard", data, status)
+}
+
+func (h *UserImportHandler) sessionToken(c *fiber.Ctx) string {
+	sess, err := sessions.SessionStart(c)
+	if err != nil {
+		return ""
+	}
+	token, _ := sess.Get(userImportSessionKey).(string)
+	return token
+}
+
+func (h *UserImportHandler) loadUpload(c *fiber.Ctx) (*csvimport.Table, string, error) {
+	token := h.sessionToken(c)
+	file, err := h.store.Open(token)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	table, err := csvimport.Read(file, userImportMaxRows)
+	if err != nil {
+		return nil, "", err
+	}
+	return table, token, nil
+}
+
+func (h *UserImportHandler) uploadMissing(c *fiber.Ctx, err error) error {
+	l := i18n.From(c)
+	if !errors.Is(err, csvimport.ErrUploadNotFound) {
+		logs.Log.Error("Kullanıcı içe aktarma dosyası okunamadı", zap.Error(err))
+	}
+	flashmessages.Error(c, l.T("import.upload_missing"))
+	return c.Redirect(userImportPath, fiber.StatusSeeOther)
+}
+
+func mappedFields(fields []csvimport.Field, mapping csvimport.Mapping) []mappedField {
+	mapped := make([]mappedField, len(fields))
+	for i, field := range fields {
+		index, ok := mapping[field.Key]
+		mapped[i] = mappedField{Field: field, Index: index, Mapped: ok}
+	}
+	return mapped
+}
+
+// csvReadError dosya okuma hatasını kullanıcıya gösterilecek mesaja çevirir;
+// encoding/csv'nin ayrıştırma hataları olduğu gibi eklenir.
+func csvReadError(l *i18n.Localizer, err error) string {
+	switch {
+	case errors.Is(err, csvimport.ErrEmptyFile):
+		return l.T("import.upload.empty")
+	case errors.Is(err, csvimport.ErrTooManyRows):
+		return l.N("import.upload.too_many_rows", userImportMaxRows)
+	}
+	return l.T("import.upload.invalid_csv", err.Error())
+}
+
+func userImportRows(table *csvimport.Table, mapping csvimport.Mapping) []services.UserImportRow {
+	rows := make([]services.UserImportRow, len(table.Rows))
+	for i, row := range table.Rows {
+		rows[i] = services.UserImportRow{
+			Line:     row.Line,
+			Name:     mapping.Value(row, "name"),
+			Account:  mapping.Value(row, "account"),
+			Password: mapping.Value(row, "password"),
+			Type:     mapping.Value(row, "type"),
+			Status:   mapping.Value(row, "status"),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"time"
+	"zatrano/pkg/export"
+	"zatrano/pkg/logs"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// exportColumn liste sayfasındaki sütun seçimi için anahtar ve etiket taşır.
+type exportColumn struct {
+	Key   string
+	Label string
+}
+
+func exportColumnsOf[T any](columns []export.Column[T]) []exportColumn {
+	result := make([]exportColumn, len(columns))
+	for i, column := range columns {
+		result[i] = exportColumn{Key: column.Key, Label: column.Label}
+	}
+	return result
+}
+
+// exportColumnKeys columns parametresini hem tekrarlı (columns=a&columns=b)
+// hem virgülle ayrılmış (columns=a,b) biçimde okur.
+func exportColumnKeys(c *fiber.Ctx) []string {
+	var keys []string
+	for _, raw := range c.Context().QueryArgs().PeekMulti("columns") {
+		for _, key := range strings.Split(string(raw), ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// streamExport yanıt başlıklarını ayarlar ve write'ı yanıt gövdesi istemciye
+// yazılırken çalıştırır. Handler o sırada döndüğü için istek context'inin
+// iptali ve sorgu süresi sınırı yerine export.Timeout kullanılır. Gövde
+// yazılmaya başladıktan sonra durum kodu değiştirilemediğinden hatalar
+// yalnızca loglanır.
+func streamExport(c *fiber.Ctx, name string, format export.Format, write func(ctx context.Context, w io.Writer) error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), export.Timeout)
+
+	c.Attachment(format.Filename(name, time.Now()))
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		if err := write(ctx, w); err != nil {
+			logs.Log.Error("Dışa aktarma yarıda kaldı",
+				zap.String("export", name),
+				zap.String("format", string(format)),
+				zap.String("request_id", logs.RequestIDFromContext(ctx)),
+				zap.Error(err),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			logs.Log.Warn("Dışa aktarma istemciye gönderilemedi", zap.String("export", name), zap.Error(err))
+		}
+	})
+	return nil
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"zatrano/database"
+	"zatrano/pkg/health"
+	"zatrano/pkg/logs"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	readinessTimeout      = 3 * time.Second
+	sessionProbeKeyPrefix = "healthcheck_probe_"
+)
+
+type HealthHandler struct {
+	db           *gorm.DB
+	sessionStore *session.Store
+	state        *health.State
+
+	// pendingMigrations ilk başarılı kontrolün sonucudur. Şema yalnızca
+	// başlangıçta migrate edildiği için çalışma sırasında değişmez; her
+	// istekte onlarca katalog sorgusu çalıştırmamak için önbelleklenir.
+	migrationsMu      sync.Mutex
+	pendingMigrations []string
+	migrationsChecked bool
+}
+
+func NewHealthHandler(db *gorm.DB, sessionStore *session.Store, state *health.State) *HealthHandler {
+	return &HealthHandler{db: db, sessionStore: sessionStore, state: state}
+}
+
+func (h *HealthHandler) Liveness(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": health.StatusUp})
+}
+
+// Readiness yük dengeleyicinin sık yokladığı uç noktadır; yalnızca ucuz
+// kontroller çalışır. Migrasyon kontrolü ilk başarılı sorgudan sonra
+// önbellekten döndüğü için bekleyen migrasyonu olan pod trafik almaz.
+func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
+	report := h.runChecks(c, []health.Check{
+		{Name: "database", Run: h.checkDatabase},
+		{Name: "session_storage", Run: h.checkSessionStorage},
+		{Name: "migrations", Run: h.checkMigrations},
+	})
+	if report.Status != health.StatusUp {
+		logs.Log.Warn("Hazırlık (readiness) kontrolü başarısız", zap.Any("report", report))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": report.Status})
+	}
+	return c.JSON(fiber.Map{"status": report.Status})
+}
+
+// Details kontrol hatalarının metinlerini de döndüğü için herkese açık
+// uygulamada değil, metrik sunucusunda veya basic auth arkasında sunulur
+// (bkz. routes.registerHealthRoutes).
+func (h *HealthHandler) Details(c *fiber.Ctx) error {
+	report := h.runChecks(c, []health.Check{
+		{Name: "database", Run: h.checkDatabase},
+		{Name: "session_storage", Run: h.checkSessionStorage},
+		{Name: "migrations", Run: h.checkMigrations},
+	})
+	statusCode := fiber.StatusOK
+	if report.Status != health.StatusUp {
+		statusCode = fiber.StatusServiceUnavailable
+	}
+	return c.Status(statusCode).JSON(report)
+}
+
+func (h *HealthHandler) runChecks(c *fiber.Ctx, checks []health.Check) health.Report {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readinessTimeout)
+	defer cancel()
+
+	return h.state.RunChecks(ctx, checks)
+}
+
+func (h *HealthHandler) checkDatabase(ctx context.Context) error {
+	if h.db == nil {
+		return errors.New("veritabanı bağlantısı başlatılmamış")
+	}
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (h *HealthHandler) checkSessionStorage(ctx context.Context) error {
+	if h.sessionStore == nil || h.sessionStore.Storage == nil {
+		return errors.New("session store başlatılmamış")
+	}
+
+	key := sessionProbeKeyPrefix + time.Now().Format("20060102150405.000000000")
+	if err := h.sessionStore.Storage.Set(key, []byte("ok"), time.Minute); err != nil {
+		return err
+	}
+	defer func() { _ = h.sessionStore.Storage.Delete(key) }()
+
+	value, err := h.sessionStore.Storage.Get(key)
+	if err != nil {
+		return err
+	}
+	if string(value) != "ok" {
+		return errors.New("session store yazılan değeri geri okuyamadı")
+	}
+	return nil
+}
+
+func (h *HealthHandler) checkMigrations(ctx context.Context) error {
+	if h.db == nil {
+		return errors.New("veritabanı bağlantısı başlatılmamış")
+	}
+	pending, err := h.loadPendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return errors.New("bekleyen migrasyonlar: " + strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// loadPendingMigrations bekleyen migrasyonları bir kez hesaplar; hata
+// alınırsa sonuç önbelleğe alınmaz ve sonraki istekte tekrar denenir.
+func (h *HealthHandler) loadPendingMigrations(ctx context.Context) ([]string, error) {
+	h.migrationsMu.Lock()
+	defer h.migrationsMu.Unlock()
+
+	if h.migrationsChecked {
+		return h.pendingMigrations, nil
+	}
+	pending, err := database.PendingMigrations(h.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	h.pendingMigrations = pending
+	h.migrationsChecked = true
+	return pending, nil
+}
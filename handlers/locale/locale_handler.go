@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/url"
+	"strings"
+	"zatrano/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LocaleHandler struct {
+	secureCookie bool
+}
+
+func NewLocaleHandler(secureCookie bool) *LocaleHandler {
+	return &LocaleHandler{secureCookie: secureCookie}
+}
+
+// SwitchLocale dil seçicide seçilen dili cookie'ye yazar ve kullanıcıyı
+// geldiği sayfaya geri gönderir. Desteklenmeyen diller yok sayılır.
+func (h *LocaleHandler) SwitchLocale(c *fiber.Ctx) error {
+	i18n.SetCookie(c, c.Params("lang"), h.secureCookie)
+	return c.Redirect(h.returnPath(c), fiber.StatusSeeOther)
+}
+
+// returnPath Referer aynı siteye aitse onun yolunu döner; açık yönlendirmeyi
+// önlemek için başka bir adrese asla geri gönderilmez.
+func (h *LocaleHandler) returnPath(c *fiber.Ctx) string {
+	referer, err := url.Parse(c.Get(fiber.HeaderReferer))
+	if err != nil || referer.Host != c.Hostname() || !strings.HasPrefix(referer.Path, "/") {
+		return "/"
+	}
+	return referer.RequestURI()
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/renderer"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,7 +10,7 @@ import (
 
 func PanelHomeHandler(c *fiber.Ctx) error {
 	mapData := fiber.Map{
-		"Title": "Aracı Ana Sayfa",
+		"Title": i18n.From(c).T("panel.title"),
 	}
 
 	return renderer.Render(c, "panel/home/home", "layouts/panel", mapData, http.StatusOK)
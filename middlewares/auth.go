@@ -2,13 +2,13 @@ package middlewares
 
 import (
 	"context"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/sessions"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AuthMiddleware(c *fiber.Ctx) error {
+func (m *Middleware) Auth(c *fiber.Ctx) error {
 	sess, err := sessions.SessionStart(c)
 	if err != nil {
 		return c.Redirect("/auth/login")
@@ -19,15 +19,16 @@ func AuthMiddleware(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login")
 	}
 
-	authService := services.NewAuthService()
-	_, err = authService.GetUserProfile(userID)
+	user, err := m.authService.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
 		_ = sess.Destroy()
 		return c.Redirect("/auth/login")
 	}
+	i18n.ApplyUser(c, user.Locale, user.Timezone)
 
-	ctx := context.WithValue(c.Context(), "user_id", userID)
+	ctx := context.WithValue(c.UserContext(), "user_id", userID)
 	c.SetUserContext(ctx)
+	c.Locals("userID", userID)
 
 	return c.Next()
 }
@@ -3,12 +3,11 @@ package middlewares
 import (
 	"zatrano/models"
 	"zatrano/pkg/sessions"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func GuestMiddleware(c *fiber.Ctx) error {
+func (m *Middleware) Guest(c *fiber.Ctx) error {
 	sess, err := sessions.SessionStart(c)
 	if err != nil {
 		return c.Next()
@@ -19,8 +18,7 @@ func GuestMiddleware(c *fiber.Ctx) error {
 		return c.Next()
 	}
 
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
+	user, err := m.authService.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
 		_ = sess.Destroy()
 		return c.Next()
@@ -0,0 +1,11 @@
+package middlewares
+
+import "zatrano/services"
+
+type Middleware struct {
+	authService services.IAuthService
+}
+
+func New(authService services.IAuthService) *Middleware {
+	return &Middleware{authService: authService}
+}
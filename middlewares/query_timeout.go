@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// QueryTimeout isteğin context'ine bir son tarih ekler. Handler'lar
+// c.UserContext() ile servis ve repository katmanına geçtiği için istek
+// içindeki tüm veritabanı sorguları bu süreyi aşınca iptal edilir.
+func QueryTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
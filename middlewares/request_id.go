@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"context"
+	"zatrano/pkg/logs"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+func RequestIDMiddleware(c *fiber.Ctx) error {
+	requestID := c.Get(requestIDHeader)
+	if requestID == "" || len(requestID) > 64 {
+		requestID = utils.UUIDv4()
+	}
+
+	c.Set(requestIDHeader, requestID)
+	c.Locals("requestid", requestID)
+
+	ctx := context.WithValue(c.UserContext(), logs.RequestIDContextKey, requestID)
+	ctx = context.WithValue(ctx, logs.ClientIPContextKey, c.IP())
+	c.SetUserContext(ctx)
+
+	return c.Next()
+}
@@ -1,13 +1,13 @@
 package middlewares
 
 import (
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/sessions"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func StatusMiddleware(c *fiber.Ctx) error {
+func (m *Middleware) Status(c *fiber.Ctx) error {
 	sess, err := sessions.SessionStart(c)
 	if err != nil {
 		return c.Redirect("/auth/login")
@@ -18,14 +18,13 @@ func StatusMiddleware(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login")
 	}
 
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
+	user, err := m.authService.GetUserProfile(c.UserContext(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bulunamadı")
+		return c.Status(fiber.StatusInternalServerError).SendString(i18n.From(c).T("access.user_not_found"))
 	}
 
 	if !user.Status {
-		return c.Status(fiber.StatusForbidden).SendString("Kullanıcı aktif değil")
+		return c.Status(fiber.StatusForbidden).SendString(i18n.From(c).T("access.user_inactive"))
 	}
 
 	return c.Next()
@@ -2,32 +2,31 @@ package middlewares
 
 import (
 	"zatrano/models"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/sessions"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func TypeMiddleware(requiredType models.UserType) fiber.Handler {
+func (m *Middleware) Type(requiredType models.UserType) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		sess, err := sessions.SessionStart(c)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).SendString("Oturum açılmamış")
+			return c.Status(fiber.StatusUnauthorized).SendString(i18n.From(c).T("access.not_logged_in"))
 		}
 
 		userID, err := sessions.GetUserIDFromSession(sess)
 		if err != nil {
-			return c.Status(fiber.StatusForbidden).SendString("Yetkisiz erişim")
+			return c.Status(fiber.StatusForbidden).SendString(i18n.From(c).T("access.unauthorized"))
 		}
 
-		authService := services.NewAuthService()
-		user, err := authService.GetUserProfile(userID)
+		user, err := m.authService.GetUserProfile(c.UserContext(), userID)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bilgileri alınamadı")
+			return c.Status(fiber.StatusInternalServerError).SendString(i18n.From(c).T("access.user_fetch_failed"))
 		}
 
 		if user.Type != requiredType {
-			return c.Status(fiber.StatusForbidden).SendString("Bu işlem için yetkiniz yok")
+			return c.Status(fiber.StatusForbidden).SendString(i18n.From(c).T("access.forbidden"))
 		}
 
 		return c.Next()
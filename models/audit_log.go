@@ -0,0 +1,111 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"zatrano/pkg/queryparams"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate      AuditAction = "create"
+	AuditActionUpdate      AuditAction = "update"
+	AuditActionDelete      AuditAction = "delete"
+	AuditActionRestore     AuditAction = "restore"
+	AuditActionForceDelete AuditAction = "force_delete"
+)
+
+var auditActions = []string{
+	string(AuditActionCreate),
+	string(AuditActionUpdate),
+	string(AuditActionDelete),
+	string(AuditActionRestore),
+	string(AuditActionForceDelete),
+}
+
+// AuditChange bir kolonun işlemden önceki ve sonraki değeridir. Oluşturmada
+// Old, kalıcı silmede New boştur.
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditChanges kolon adına göre değişiklikleri tutar ve jsonb olarak saklanır.
+type AuditChanges map[string]AuditChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	return string(data), err
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	return scanJSON(value, c)
+}
+
+// scanJSON jsonb kolonlarını dest'e çözer; NULL değer dest'i değiştirmez.
+func scanJSON(value interface{}, dest interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("jsonb kolonu için desteklenmeyen veri tipi")
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// AuditLog modellerdeki her oluşturma, güncelleme ve silme işleminin kaydıdır.
+// pkg/audit tarafından GORM callback'leriyle otomatik yazılır; kendisi audit
+// edilmez ve BaseModel kullanmaz (silinmez, güncellenmez).
+type AuditLog struct {
+	ID        uint         `gorm:"primarykey"`
+	CreatedAt time.Time    `gorm:"index"`
+	ActorID   *uint        `gorm:"index"`
+	RequestID string       `gorm:"size:64"`
+	IP        string       `gorm:"size:64"`
+	Action    AuditAction  `gorm:"size:20;not null;index"`
+	Table     string       `gorm:"column:table_name;size:100;not null;index:idx_audit_logs_record,priority:1"`
+	RecordID  string       `gorm:"size:100;not null;index:idx_audit_logs_record,priority:2"`
+	Changes   AuditChanges `gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+// AuditExempt audit callback'lerinin bu modeli atlamasını sağlar.
+func (AuditLog) AuditExempt() {}
+
+// Actor işlemi yapan kullanıcının ID'sini, sistem işlemlerinde 0 döner.
+func (l AuditLog) Actor() uint {
+	if l.ActorID == nil {
+		return 0
+	}
+	return *l.ActorID
+}
+
+func (AuditLog) ListSpec() queryparams.ListSpec {
+	return queryparams.ListSpec{
+		Filters: map[string]queryparams.FilterSpec{
+			"table":      {Column: "table_name", Type: queryparams.FilterEq},
+			"record_id":  {Column: "record_id", Type: queryparams.FilterEq},
+			"actor_id":   {Column: "actor_id", Type: queryparams.FilterEq},
+			"action":     {Column: "action", Type: queryparams.FilterIn, Values: auditActions},
+			"request_id": {Column: "request_id", Type: queryparams.FilterEq},
+			"created_at": {Column: "created_at", Type: queryparams.FilterDateRange},
+		},
+		Sorts: map[string]string{
+			"id":         "id",
+			"created_at": "created_at",
+		},
+		DefaultSort: "-id",
+		CountMode:   queryparams.CountEstimate,
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -15,6 +16,19 @@ const (
 
 const contextUserIDKey = "user_id"
 
+type systemActorKey struct{}
+
+// WithSystemActor, oturum açmış bir kullanıcı olmadan çalışan işlemler
+// (seeder, bakım görevleri) için created_by/updated_by zorunluluğunu kaldırır.
+func WithSystemActor(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemActorKey{}, true)
+}
+
+func isSystemActor(ctx context.Context) bool {
+	system, _ := ctx.Value(systemActorKey{}).(bool)
+	return system
+}
+
 type BaseModel struct {
 	ID        uint `gorm:"primarykey"`
 	CreatedAt time.Time
@@ -23,6 +37,9 @@ type BaseModel struct {
 	CreatedBy uint
 	UpdatedBy uint
 	DeletedBy *uint `gorm:"column:deleted_by"`
+	// Version her güncellemede bir artar; eşzamanlı düzenlemelerde son
+	// yazanın diğerini fark etmeden ezmesini önlemek için kullanılır.
+	Version uint `gorm:"not null;default:1"`
 }
 
 func (b *BaseModel) BeforeCreate(tx *gorm.DB) (err error) {
@@ -30,7 +47,7 @@ func (b *BaseModel) BeforeCreate(tx *gorm.DB) (err error) {
 	if ok && userID != 0 {
 		b.CreatedBy = userID
 		b.UpdatedBy = userID
-	} else {
+	} else if !isSystemActor(tx.Statement.Context) {
 		return errors.New("BeforeCreate: kullanıcı kimliği bulunamadı")
 	}
 	return nil
@@ -40,7 +57,7 @@ func (b *BaseModel) BeforeUpdate(tx *gorm.DB) (err error) {
 	userID, ok := tx.Statement.Context.Value(contextUserIDKey).(uint)
 	if ok && userID != 0 {
 		tx.Statement.SetColumn(updatedByColumn, userID)
-	} else {
+	} else if !isSystemActor(tx.Statement.Context) {
 		return errors.New("BeforeUpdate: kullanıcı kimliği bulunamadı")
 	}
 	return nil
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// Historied sürüm geçmişi tutulan modellerin arayüzüdür. BaseModel boş tablo
+// adı döner (geçmiş kapalı); geçmiş isteyen model kendi *_versions tablosunun
+// adını dönerek katılır.
+type Historied interface {
+	HistoryTable() string
+}
+
+func (BaseModel) HistoryTable() string {
+	return ""
+}
+
+// Snapshot bir kaydın kolon adına göre tüm değerleridir ve jsonb olarak saklanır.
+type Snapshot map[string]interface{}
+
+func (s Snapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+func (s *Snapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+// RecordVersion bir kaydın belirli bir sürümdeki tam anlık görüntüsüdür.
+// Her Historied model için ayrı bir tabloda (ör. user_versions) tutulur ve
+// pkg/audit eklentisi tarafından oluşturma ve sürüm artıran her güncellemede
+// yazılır. `audit:"-"` etiketli alanlar anlık görüntüye girmez.
+type RecordVersion struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	RecordID  uint `gorm:"not null"`
+	Version   uint `gorm:"not null"`
+	ActorID   *uint
+	RequestID string   `gorm:"size:64"`
+	Snapshot  Snapshot `gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+// AuditExempt sürüm kayıtlarının ayrıca audit edilmesini engeller.
+func (RecordVersion) AuditExempt() {}
+
+func (v RecordVersion) Actor() uint {
+	if v.ActorID == nil {
+		return 0
+	}
+	return *v.ActorID
+}
+
+// Diff bu sürümü bir önceki sürümle karşılaştırır; previous nil ise (ilk
+// sürüm) tüm değerler yeni kabul edilir. Zaman damgası ve sürüm kolonları
+// karşılaştırmaya alınmaz.
+func (v RecordVersion) Diff(previous *RecordVersion) AuditChanges {
+	changes := AuditChanges{}
+	for column, value := range v.Snapshot {
+		if versionNoise[column] {
+			continue
+		}
+		var old interface{}
+		if previous != nil {
+			old = previous.Snapshot[column]
+		}
+		if previous == nil && value == nil {
+			continue
+		}
+		if previous != nil && jsonEqual(old, value) {
+			continue
+		}
+		changes[column] = AuditChange{Old: old, New: value}
+	}
+	return changes
+}
+
+var versionNoise = map[string]bool{
+	"created_at": true, "updated_at": true, "created_by": true, "updated_by": true, "version": true,
+}
+
+func jsonEqual(a, b interface{}) bool {
+	left, _ := json.Marshal(a)
+	right, _ := json.Marshal(b)
+	return string(left) == string(right)
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"zatrano/pkg/queryparams"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -26,10 +28,45 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 type User struct {
 	BaseModel
 	Name     string   `gorm:"size:100;not null;index"`
-	Account  string   `gorm:"size:100;unique;not null"`
-	Password string   `gorm:"size:255;not null"`
+	Account  string   `gorm:"size:100;not null;uniqueIndex:idx_users_account_active,where:deleted_at IS NULL"`
+	Password string   `gorm:"size:255;not null" audit:"-"`
 	Status   bool     `gorm:"default:true;index"`
 	Type     UserType `gorm:"type:user_type;not null;default:'panel';index"`
+
+	// Locale ve Timezone kullanıcının profilinde seçtiği arayüz dili ve IANA
+	// saat dilimidir. Boşsa dil dil seçiciden veya tarayıcıdan, saat dilimi
+	// yapılandırmadaki varsayılandan alınır.
+	Locale   string `gorm:"size:16;not null;default:''"`
+	Timezone string `gorm:"size:64;not null;default:''"`
+}
+
+func (User) HistoryTable() string {
+	return "user_versions"
+}
+
+func (User) ListSpec() queryparams.ListSpec {
+	return queryparams.ListSpec{
+		Filters: map[string]queryparams.FilterSpec{
+			"name":       {Column: "name", Type: queryparams.FilterLike},
+			"account":    {Column: "account", Type: queryparams.FilterLike},
+			"status":     {Column: "status", Type: queryparams.FilterBool},
+			"type":       {Column: "type", Type: queryparams.FilterIn, Values: []string{string(Dashboard), string(Panel)}},
+			"id":         {Column: "id", Type: queryparams.FilterRange},
+			"created_at": {Column: "created_at", Type: queryparams.FilterDateRange},
+		},
+		Sorts: map[string]string{
+			"id":         "id",
+			"name":       "name",
+			"account":    "account",
+			"type":       "type",
+			"status":     "status",
+			"created_at": "created_at",
+		},
+		SearchColumns: []string{"name", "account"},
+		SearchFuzzy:   true,
+		SearchRanked:  true,
+		DefaultSort:   "-id",
+	}
 }
 
 func (u *User) CheckPassword(password string) error {
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"reflect"
+
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+func historyTable(stmt *gorm.Statement) string {
+	if historied, ok := reflect.New(stmt.Schema.ModelType).Interface().(models.Historied); ok {
+		return historied.HistoryTable()
+	}
+	return ""
+}
+
+// recordVersions Historied modellerde yeni oluşturulan veya sürümü artan
+// satırların tam anlık görüntüsünü modelin geçmiş tablosuna yazar. Sürümü
+// değişmeyen güncellemeler (ör. yalnızca deleted_by) yeni sürüm sayılmaz.
+func recordVersions(tx *gorm.DB, before, after map[string]map[string]interface{}) {
+	table := historyTable(tx.Statement)
+	if table == "" {
+		return
+	}
+
+	primaryKey := tx.Statement.Schema.PrioritizedPrimaryField.DBName
+	excluded := excludedColumns(tx.Statement)
+	ctx := tx.Statement.Context
+
+	var versions []models.RecordVersion
+	for id, row := range after {
+		if old, exists := before[id]; exists && equal(old["version"], row["version"]) {
+			continue
+		}
+		snapshot := make(models.Snapshot, len(row))
+		for column, value := range row {
+			if !excluded[column] {
+				snapshot[column] = value
+			}
+		}
+		version := models.RecordVersion{
+			RecordID:  toUint(row[primaryKey]),
+			Version:   toUint(row["version"]),
+			RequestID: logs.RequestIDFromContext(ctx),
+			Snapshot:  snapshot,
+		}
+		if actorID := actorFromContext(ctx); actorID != 0 {
+			version.ActorID = &actorID
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return
+	}
+	if err := tx.Session(&gorm.Session{NewDB: true}).Table(table).Create(&versions).Error; err != nil {
+		fail(tx, err)
+	}
+}
+
+func toUint(value interface{}) uint {
+	switch v := value.(type) {
+	case int64:
+		return uint(v)
+	case int32:
+		return uint(v)
+	case int:
+		return uint(v)
+	case uint:
+		return v
+	case uint64:
+		return uint(v)
+	}
+	return 0
+}
@@ -0,0 +1,361 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	contextUserIDKey = "user_id"
+	beforeKey        = "audit:before"
+	commitCallback   = "gorm:commit_or_rollback_transaction"
+)
+
+// updateNoise güncelleme farkında gösterilmeyen kolonlardır; işlemi yapan ve
+// zamanı zaten audit kaydının kendisinde bulunur, sürüm her güncellemede artar.
+var updateNoise = map[string]bool{"updated_at": true, "updated_by": true, "version": true}
+
+// exempt audit edilmeyecek modellerin uyguladığı arayüzdür (ör. AuditLog).
+type exempt interface {
+	AuditExempt()
+}
+
+// Plugin her create/update/delete işleminden sonra audit_logs tablosuna kayıt
+// yazan GORM eklentisidir. Eski değerler işlemden önce aynı koşullarla
+// okunur, yeni değerler işlemden sonra tekrar okunur ve yalnızca değişen
+// kolonlar saklanır. `audit:"-"` etiketli alanlar (ör. şifre) hiç yazılmaz.
+// Kayıt işlemle aynı transaction'da yazılır; yazılamazsa işlem de geri alınır.
+// Historied modeller için ayrıca her sürümün tam anlık görüntüsü saklanır.
+type Plugin struct{}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Name() string {
+	return "audit"
+}
+
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:create").Before(commitCallback).Register("audit:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Before(commitCallback).Register("audit:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", p.before); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Before(commitCallback).Register("audit:after_delete", p.afterDelete)
+}
+
+func (p *Plugin) afterCreate(tx *gorm.DB) {
+	if !audited(tx) || tx.Error != nil {
+		return
+	}
+	ids := primaryKeys(tx.Statement)
+	if len(ids) == 0 {
+		return
+	}
+	after, err := snapshot(tx, idCondition(tx.Statement, ids))
+	if err != nil {
+		fail(tx, err)
+		return
+	}
+
+	excluded := excludedColumns(tx.Statement)
+	var entries []models.AuditLog
+	for id, row := range after {
+		changes := models.AuditChanges{}
+		for column, value := range row {
+			if !excluded[column] && value != nil {
+				changes[column] = models.AuditChange{New: value}
+			}
+		}
+		entries = append(entries, newEntry(tx, models.AuditActionCreate, id, changes))
+	}
+	write(tx, entries)
+	recordVersions(tx, nil, after)
+}
+
+// before işlemden etkilenecek satırların mevcut halini okur.
+func (p *Plugin) before(tx *gorm.DB) {
+	if !audited(tx) || tx.Error != nil {
+		return
+	}
+	conditions := targetConditions(tx.Statement)
+	if len(conditions) == 0 {
+		return
+	}
+	rows, err := snapshot(tx, conditions...)
+	if err != nil {
+		fail(tx, err)
+		return
+	}
+	tx.InstanceSet(beforeKey, rows)
+}
+
+func (p *Plugin) afterUpdate(tx *gorm.DB) {
+	before, after, ok := beforeAndAfter(tx)
+	if !ok {
+		return
+	}
+
+	excluded := excludedColumns(tx.Statement)
+	var entries []models.AuditLog
+	for id, old := range before {
+		current, exists := after[id]
+		if !exists {
+			continue
+		}
+		changes := diff(old, current, excluded, updateNoise)
+		if len(changes) == 0 {
+			continue
+		}
+		action := models.AuditActionUpdate
+		if old["deleted_at"] != nil && current["deleted_at"] == nil {
+			action = models.AuditActionRestore
+		}
+		entries = append(entries, newEntry(tx, action, id, changes))
+	}
+	write(tx, entries)
+	recordVersions(tx, before, after)
+}
+
+// afterDelete soft delete ile işaretlenen satırlar için değişen kolonları,
+// kalıcı silinen satırlar için son değerlerin tamamını yazar.
+func (p *Plugin) afterDelete(tx *gorm.DB) {
+	before, after, ok := beforeAndAfter(tx)
+	if !ok {
+		return
+	}
+
+	excluded := excludedColumns(tx.Statement)
+	var entries []models.AuditLog
+	for id, old := range before {
+		current, exists := after[id]
+		if exists {
+			changes := diff(old, current, excluded, updateNoise)
+			if len(changes) > 0 {
+				entries = append(entries, newEntry(tx, models.AuditActionDelete, id, changes))
+			}
+			continue
+		}
+		changes := models.AuditChanges{}
+		for column, value := range old {
+			if !excluded[column] {
+				changes[column] = models.AuditChange{Old: value}
+			}
+		}
+		entries = append(entries, newEntry(tx, models.AuditActionForceDelete, id, changes))
+	}
+	write(tx, entries)
+}
+
+func beforeAndAfter(tx *gorm.DB) (before, after map[string]map[string]interface{}, ok bool) {
+	if !audited(tx) || tx.Error != nil {
+		return nil, nil, false
+	}
+	value, found := tx.InstanceGet(beforeKey)
+	if !found {
+		return nil, nil, false
+	}
+	before, _ = value.(map[string]map[string]interface{})
+	if len(before) == 0 {
+		return nil, nil, false
+	}
+
+	ids := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		ids = append(ids, row[tx.Statement.Schema.PrioritizedPrimaryField.DBName])
+	}
+	after, err := snapshot(tx, idCondition(tx.Statement, ids))
+	if err != nil {
+		fail(tx, err)
+		return nil, nil, false
+	}
+	return before, after, true
+}
+
+func audited(tx *gorm.DB) bool {
+	stmt := tx.Statement
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil || tx.DryRun {
+		return false
+	}
+	_, skip := reflect.New(stmt.Schema.ModelType).Interface().(exempt)
+	return !skip
+}
+
+// targetConditions işlemin etkileyeceği satırları seçen koşulları döner:
+// sorgudaki WHERE ve modelde dolu birincil anahtarlar (Save, Delete(&entity)).
+func targetConditions(stmt *gorm.Statement) []clause.Expression {
+	var conditions []clause.Expression
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			conditions = append(conditions, where)
+		}
+	}
+	if ids := primaryKeys(stmt); len(ids) > 0 {
+		conditions = append(conditions, idCondition(stmt, ids))
+	}
+	return conditions
+}
+
+func primaryKeys(stmt *gorm.Statement) []interface{} {
+	field := stmt.Schema.PrioritizedPrimaryField
+	var ids []interface{}
+	collect := func(value reflect.Value) {
+		if id, zero := field.ValueOf(stmt.Context, value); !zero {
+			ids = append(ids, id)
+		}
+	}
+
+	value := reflect.Indirect(stmt.ReflectValue)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if item := reflect.Indirect(value.Index(i)); item.Kind() == reflect.Struct {
+				collect(item)
+			}
+		}
+	case reflect.Struct:
+		collect(value)
+	}
+	return ids
+}
+
+func idCondition(stmt *gorm.Statement, ids []interface{}) clause.Expression {
+	return clause.IN{Column: clause.Column{Name: stmt.Schema.PrioritizedPrimaryField.DBName}, Values: ids}
+}
+
+// snapshot koşula uyan satırları (silinmişler dahil) birincil anahtar
+// değerine göre döner. Okuma replikaya değil birincil veritabanına gider.
+func snapshot(tx *gorm.DB, conditions ...clause.Expression) (map[string]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Table(tx.Statement.Table).
+		Clauses(dbresolver.Write).
+		Clauses(conditions...).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	primaryKey := tx.Statement.Schema.PrioritizedPrimaryField.DBName
+	result := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		for column, value := range row {
+			row[column] = normalize(value)
+		}
+		result[fmt.Sprint(row[primaryKey])] = row
+	}
+	return result, nil
+}
+
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.UTC()
+	}
+	return value
+}
+
+func diff(old, current map[string]interface{}, skip ...map[string]bool) models.AuditChanges {
+	changes := models.AuditChanges{}
+	for column, newValue := range current {
+		if skipped(column, skip) {
+			continue
+		}
+		oldValue := old[column]
+		if equal(oldValue, newValue) {
+			continue
+		}
+		changes[column] = models.AuditChange{Old: oldValue, New: newValue}
+	}
+	return changes
+}
+
+func skipped(column string, sets []map[string]bool) bool {
+	for _, set := range sets {
+		if set[column] {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func excludedColumns(stmt *gorm.Statement) map[string]bool {
+	excluded := make(map[string]bool)
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName != "" && field.Tag.Get("audit") == "-" {
+			excluded[field.DBName] = true
+		}
+	}
+	return excluded
+}
+
+func newEntry(tx *gorm.DB, action models.AuditAction, recordID string, changes models.AuditChanges) models.AuditLog {
+	ctx := tx.Statement.Context
+	entry := models.AuditLog{
+		RequestID: logs.RequestIDFromContext(ctx),
+		IP:        logs.ClientIPFromContext(ctx),
+		Action:    action,
+		Table:     tx.Statement.Table,
+		RecordID:  recordID,
+		Changes:   changes,
+	}
+	if actorID := actorFromContext(ctx); actorID != 0 {
+		entry.ActorID = &actorID
+	}
+	return entry
+}
+
+func actorFromContext(ctx context.Context) uint {
+	if ctx == nil {
+		return 0
+	}
+	userID, _ := ctx.Value(contextUserIDKey).(uint)
+	return userID
+}
+
+func write(tx *gorm.DB, entries []models.AuditLog) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := tx.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		fail(tx, err)
+	}
+}
+
+func fail(tx *gorm.DB, err error) {
+	logs.Log.Error("Audit kaydı yazılamadı",
+		zap.String("table", tx.Statement.Table),
+		zap.String("request_id", logs.RequestIDFromContext(tx.Statement.Context)),
+		zap.Error(err),
+	)
+	_ = tx.AddError(fmt.Errorf("audit kaydı yazılamadı: %w", err))
+}
@@ -1,9 +1,9 @@
 package constants
 
 const (
-	DefaultOrderBy = "desc"
-	DefaultSortBy  = "id"
 	DefaultPage    = 1
 	DefaultPerPage = 20
 	MaxPerPage     = 100
+
+	MinPasswordLength = 6
 )
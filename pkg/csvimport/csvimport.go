@@ -0,0 +1,185 @@
+package csvimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"zatrano/pkg/turkishsearch"
+)
+
+var (
+	ErrEmptyFile   = errors.New("dosya boş veya başlık satırı yok")
+	ErrTooManyRows = errors.New("dosyadaki satır sayısı izin verilen sınırı aşıyor")
+)
+
+const utf8BOM = "\xEF\xBB\xBF"
+
+// Row dosyadaki bir veri satırıdır. Line, başlık dahil 1'den başlayan satır
+// numarasıdır; hata raporlarında kullanıcıya bu gösterilir.
+type Row struct {
+	Line   int
+	Values []string
+}
+
+// Table başlık satırı ve veri satırlarıyla okunmuş bir CSV dosyasıdır.
+type Table struct {
+	Header []string
+	Rows   []Row
+}
+
+// Read CSV dosyasını okur. Excel'in eklediği BOM atlanır; ayraç başlık
+// satırına bakılarak virgül, noktalı virgül (Türkçe Excel) veya sekme olarak
+// seçilir. Tamamen boş satırlar atlanır.
+func Read(r io.Reader, maxRows int) (*Table, error) {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	firstLine, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	reader := csv.NewReader(br)
+	reader.Comma = detectDelimiter(firstLine)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyFile
+	}
+	if err != nil {
+		return nil, err
+	}
+	table := &Table{Header: trimAll(header)}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isBlank(record) {
+			continue
+		}
+		if len(table.Rows) >= maxRows {
+			return nil, fmt.Errorf("%w (en fazla %d satır)", ErrTooManyRows, maxRows)
+		}
+		line, _ := reader.FieldPos(0)
+		table.Rows = append(table.Rows, Row{Line: line, Values: trimAll(record)})
+	}
+	return table, nil
+}
+
+func detectDelimiter(line []byte) rune {
+	best, bestCount := ',', bytes.Count(line, []byte{','})
+	for _, candidate := range []rune{';', '\t'} {
+		if count := bytes.Count(line, []byte(string(candidate))); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+func trimAll(values []string) []string {
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+func isBlank(values []string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Field içe aktarılacak modeldeki bir alandır. Aliases, başlık satırında bu
+// alana otomatik eşlenecek sütun adlarıdır (küçük harfle).
+type Field struct {
+	Key      string
+	Label    string
+	Required bool
+	Aliases  []string
+}
+
+// Mapping alan anahtarından sütun indeksine eşlemedir; eşlenmemiş alan
+// haritada yer almaz.
+type Mapping map[string]int
+
+// GuessMapping başlıktaki sütun adlarını alanların anahtar, etiket ve
+// takma adlarıyla büyük/küçük harf duyarsız karşılaştırarak eşler.
+func GuessMapping(header []string, fields []Field) Mapping {
+	mapping := Mapping{}
+	for _, field := range fields {
+		names := append([]string{field.Key, field.Label}, field.Aliases...)
+		for i, column := range header {
+			if matchesAny(column, names) {
+				mapping[field.Key] = i
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// matchesAny başlığı Türkçe kurallarla katlayarak karşılaştırır; "AD SOYAD",
+// "Ad Soyad" ve "ad soyad" aynı kabul edilir, "HESAP TÜRÜ" "hesap turu" ile eşleşir.
+func matchesAny(column string, names []string) bool {
+	column = turkishsearch.Fold(strings.TrimSpace(column))
+	for _, name := range names {
+		if column == turkishsearch.Fold(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMapping get ile okunan "map_<alan>" değerlerinden eşleme oluşturur;
+// boş veya sütun aralığı dışındaki değerler alanı eşlenmemiş bırakır.
+func ParseMapping(fields []Field, columns int, get func(key string) string) Mapping {
+	mapping := Mapping{}
+	for _, field := range fields {
+		index, err := strconv.Atoi(get("map_" + field.Key))
+		if err != nil || index < 0 || index >= columns {
+			continue
+		}
+		mapping[field.Key] = index
+	}
+	return mapping
+}
+
+// Missing eşlenmemiş zorunlu alanları döner.
+func (m Mapping) Missing(fields []Field) []Field {
+	var missing []Field
+	for _, field := range fields {
+		if _, ok := m[field.Key]; field.Required && !ok {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// Value satırın key alanına eşlenmiş sütundaki değeri döner.
+func (m Mapping) Value(row Row, key string) string {
+	index, ok := m[key]
+	if !ok || index >= len(row.Values) {
+		return ""
+	}
+	return row.Values[index]
+}
@@ -0,0 +1,131 @@
+package csvimport
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrUploadNotFound = errors.New("içe aktarma dosyası bulunamadı veya süresi doldu")
+
+var tokenPattern = regexp.MustCompile(`^[a-f0-9]{32}$`)
+
+// Store yüklenen dosyaları önizleme ve içe aktarma adımları arasında
+// geçici dizinde tutar. Dosyalar şifre gibi gizli alanlar içerebileceği
+// için yalnızca sahibi okuyabilir; MaxAge'den eski olanlar açılmaz ve
+// Cleanup ile silinir.
+type Store struct {
+	Dir    string
+	MaxAge time.Duration
+}
+
+func NewStore(name string, maxAge time.Duration) *Store {
+	return &Store{Dir: filepath.Join(os.TempDir(), name), MaxAge: maxAge}
+}
+
+// Save data'yı yeni bir anahtarla kaydeder ve anahtarı döner.
+func (s *Store) Save(data []byte) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return "", err
+	}
+	s.Cleanup()
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+	if err := os.WriteFile(s.path(token, "upload"), data, 0o600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Open token'a ait yüklenen dosyayı açar.
+func (s *Store) Open(token string) (*os.File, error) {
+	return s.open(token, "upload")
+}
+
+// SaveResult içe aktarma sonucunu write ile yazar ve yüklenen dosyayı siler.
+func (s *Store) SaveResult(token string, write func(w io.Writer) error) error {
+	if !tokenPattern.MatchString(token) {
+		return ErrUploadNotFound
+	}
+	file, err := os.OpenFile(s.path(token, "result"), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	_ = os.Remove(s.path(token, "upload"))
+	return nil
+}
+
+// OpenResult token'a ait sonuç dosyasını açar.
+func (s *Store) OpenResult(token string) (*os.File, error) {
+	return s.open(token, "result")
+}
+
+func (s *Store) open(token, kind string) (*os.File, error) {
+	if !tokenPattern.MatchString(token) {
+		return nil, ErrUploadNotFound
+	}
+	path := s.path(token, kind)
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	// Süresi dolmuş dosya Cleanup'ın bir sonraki turunu beklemeden silinir.
+	if s.expired(info) {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return nil, ErrUploadNotFound
+	}
+	return file, nil
+}
+
+func (s *Store) path(token, kind string) string {
+	return filepath.Join(s.Dir, token+"."+kind+".csv")
+}
+
+// Cleanup MaxAge'den eski dosyaları siler. Save her yüklemede çağırır;
+// yarım bırakılmış yüklemeler yeni yükleme beklemeden silinsin diye
+// uygulama da periyodik olarak çağırmalıdır.
+func (s *Store) Cleanup() {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".csv") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && s.expired(info) {
+			_ = os.Remove(filepath.Join(s.Dir, entry.Name()))
+		}
+	}
+}
+
+func (s *Store) expired(info fs.FileInfo) bool {
+	return info.ModTime().Before(time.Now().Add(-s.MaxAge))
+}
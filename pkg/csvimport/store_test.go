@@ -0,0 +1,178 @@
+package csvimport
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	store := &Store{Dir: filepath.Join(t.TempDir(), "imports"), MaxAge: time.Hour}
+
+	token, err := store.Save([]byte("ad,hesap\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tokenPattern.MatchString(token) {
+		t.Fatalf("Save geçersiz anahtar döndü: %q", token)
+	}
+	info, err := os.Stat(store.path(token, "upload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("yüklenen dosya izni = %o, 600 bekleniyordu", perm)
+	}
+
+	file, err := store.Open(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(file)
+	_ = file.Close()
+	if string(data) != "ad,hesap\n" {
+		t.Errorf("Open içeriği = %q", data)
+	}
+
+	if err := store.SaveResult(token, func(w io.Writer) error {
+		_, err := io.WriteString(w, "sonuç")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(token); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("SaveResult sonrası yüklenen dosya duruyor: %v", err)
+	}
+	result, err := store.OpenResult(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+	if data, _ := io.ReadAll(result); string(data) != "sonuç" {
+		t.Errorf("OpenResult içeriği = %q", data)
+	}
+}
+
+func TestStoreRejectsInvalidTokens(t *testing.T) {
+	dir := t.TempDir()
+	store := &Store{Dir: filepath.Join(dir, "imports"), MaxAge: time.Hour}
+	if err := os.WriteFile(filepath.Join(dir, "secret.upload.csv"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range []string{
+		"",
+		"../secret",
+		strings.Repeat("a", 31),
+		strings.Repeat("a", 33),
+		strings.Repeat("A", 32),
+		strings.Repeat("g", 32),
+		strings.Repeat("a", 31) + "\n",
+	} {
+		if _, err := store.Open(token); !errors.Is(err, ErrUploadNotFound) {
+			t.Errorf("Open(%q) hatası = %v", token, err)
+		}
+		if _, err := store.OpenResult(token); !errors.Is(err, ErrUploadNotFound) {
+			t.Errorf("OpenResult(%q) hatası = %v", token, err)
+		}
+		if err := store.SaveResult(token, func(io.Writer) error { return nil }); !errors.Is(err, ErrUploadNotFound) {
+			t.Errorf("SaveResult(%q) hatası = %v", token, err)
+		}
+	}
+	if _, err := store.Open(strings.Repeat("a", 32)); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("olmayan dosya hatası = %v", err)
+	}
+}
+
+func TestStoreCleanup(t *testing.T) {
+	store := &Store{Dir: t.TempDir(), MaxAge: time.Hour}
+	old := time.Now().Add(-2 * time.Hour)
+
+	write := func(name string, modTime time.Time) string {
+		path := filepath.Join(store.Dir, name)
+		if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	expired := write(strings.Repeat("a", 32)+".upload.csv", old)
+	expiredResult := write(strings.Repeat("b", 32)+".result.csv", old)
+	fresh := write(strings.Repeat("c", 32)+".upload.csv", time.Now().Add(-30*time.Minute))
+	other := write("notlar.txt", old)
+
+	store.Cleanup()
+	for path, wantExists := range map[string]bool{expired: false, expiredResult: false, fresh: true, other: true} {
+		if _, err := os.Stat(path); (err == nil) != wantExists {
+			t.Errorf("%s var mı = %v, want %v", filepath.Base(path), err == nil, wantExists)
+		}
+	}
+}
+
+func TestStoreSaveCleansUp(t *testing.T) {
+	store := &Store{Dir: t.TempDir(), MaxAge: time.Hour}
+	old := time.Now().Add(-2 * time.Hour)
+	expired := filepath.Join(store.Dir, strings.Repeat("a", 32)+".upload.csv")
+	if err := os.WriteFile(expired, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(expired, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Save([]byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(expired); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Save süresi dolmuş dosyayı silmedi: %v", err)
+	}
+}
+
+func TestStoreOpenRejectsExpired(t *testing.T) {
+	store := &Store{Dir: t.TempDir(), MaxAge: time.Hour}
+	token, err := store.Save([]byte("ad,şifre\nAyşe,gizli\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveResult(token, func(w io.Writer) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	path := store.path(token, "result")
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.OpenResult(token); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("süresi dolmuş dosya açıldı: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("süresi dolmuş dosya silinmedi: %v", err)
+	}
+
+	token, err = store.Save([]byte("x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old = time.Now().Add(-61 * time.Minute)
+	if err := os.Chtimes(store.path(token, "upload"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(token); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("süresi dolmuş yükleme açıldı: %v", err)
+	}
+}
+
+func TestReadEmptyFile(t *testing.T) {
+	for _, data := range []string{"", utf8BOM, "\n\n"} {
+		if _, err := Read(bytes.NewReader([]byte(data)), 10); !errors.Is(err, ErrEmptyFile) {
+			t.Errorf("Read(%q) hatası = %v, ErrEmptyFile bekleniyordu", data, err)
+		}
+	}
+}
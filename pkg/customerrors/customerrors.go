@@ -1,27 +1,114 @@
 package customerrors
 
-import "errors"
+import "fmt"
 
+// Error kullanıcıya gösterilebilen bir hatadır. Error() loglarda kullanılan
+// Türkçe metni döner; arayüzde i18n paketi Key ile hatayı isteğin diline
+// çevirir.
+type Error struct {
+	Key     string
+	Message string
+	Args    []interface{}
+
+	base *Error
+}
+
+func New(key, message string) *Error {
+	return &Error{Key: key, Message: message}
+}
+
+func (e *Error) Error() string {
+	if len(e.Args) > 0 {
+		return fmt.Sprintf(e.Message, e.Args...)
+	}
+	return e.Message
+}
+
+// With mesajdaki yer tutucular için args ile hatanın bir kopyasını döner;
+// kopya errors.Is ile orijinal hataya eşittir.
+func (e *Error) With(args ...interface{}) *Error {
+	base := e
+	if e.base != nil {
+		base = e.base
+	}
+	return &Error{Key: e.Key, Message: e.Message, Args: args, base: base}
+}
+
+func (e *Error) Is(target error) bool {
+	return e.base != nil && target == error(e.base)
+}
+
+func (e *Error) MessageKey() string {
+	return e.Key
+}
+
+func (e *Error) MessageArgs() []interface{} {
+	return e.Args
+}
+
+var (
+	ErrRepoRecordNotFound       = New("errors.record_not_found", "kayıt bulunamadı")
+	ErrInvalidUserContext       = New("errors.invalid_user_context", "geçersiz kullanıcı context bilgisi")
+	ErrUserServiceUserNotFound  = New("errors.user_not_found", "kullanıcı bulunamadı")
+	ErrPasswordHashingFailed    = New("errors.password_hashing_failed", "şifre oluşturulurken bir hata oluştu")
+	ErrPasswordUpdateFailed     = New("errors.password_update_failed", "şifre güncellenirken bir hata oluştu")
+	ErrUserCreationFailed       = New("errors.user_creation_failed", "kullanıcı veritabanına kaydedilemedi")
+	ErrUserUpdateFailed         = New("errors.user_update_failed", "kullanıcı veritabanında güncellenemedi")
+	ErrUserDeletionFailed       = New("errors.user_deletion_failed", "kullanıcı silinirken bir veritabanı hatası oluştu")
+	ErrPasswordRequired         = New("errors.password_required", "şifre alanı boş olamaz")
+	ErrContextUserIDNotFound    = New("errors.context_user_id_not_found", "işlemi yapan kullanıcı kimliği context içinde bulunamadı")
+	ErrInvalidCredentials       = New("errors.invalid_credentials", "geçersiz kimlik bilgileri")
+	ErrUserNotFound             = New("errors.user_not_found", "kullanıcı bulunamadı")
+	ErrUserInactive             = New("errors.user_inactive", "kullanıcı aktif değil")
+	ErrCurrentPasswordIncorrect = New("errors.current_password_incorrect", "mevcut şifre hatalı")
+	ErrPasswordTooShort         = New("errors.password_too_short", "yeni şifre en az 6 karakter olmalıdır")
+	ErrPasswordSameAsOld        = New("errors.password_same_as_old", "yeni şifre mevcut şifre ile aynı olamaz")
+	ErrInvalidLocale            = New("errors.invalid_locale", "seçilen dil desteklenmiyor")
+	ErrInvalidTimezone          = New("errors.invalid_timezone", "seçilen saat dilimi geçerli değil")
+	ErrAuthGeneric              = New("errors.auth_generic", "kimlik doğrulaması sırasında bir hata oluştu")
+	ErrProfileGeneric           = New("errors.profile_generic", "profil bilgileri alınırken hata")
+	ErrUpdatePasswordGeneric    = New("errors.password_update_failed", "şifre güncellenirken bir hata oluştu")
+	ErrHashingFailed            = New("errors.hashing_failed", "yeni şifre oluşturulurken hata")
+	ErrDatabaseUpdateFailed     = New("errors.database_update_failed", "veritabanı güncellemesi başarısız oldu")
+	ErrVersionConflict          = New("errors.version_conflict", "kayıt siz düzenlerken başka biri tarafından değiştirildi")
+	ErrVersionRequired          = New("errors.version_required", "güncellenecek kaydın sürümü belirtilmeli")
+	ErrUserVersionConflict      = New("errors.user_version_conflict", "kullanıcı siz düzenlerken başka biri tarafından güncellendi")
+	ErrUserVersionRequired      = New("errors.user_version_required", "formda kullanıcının sürüm bilgisi yok; sayfayı yenileyip tekrar deneyin")
+	ErrUserVersionNotFound      = New("errors.user_version_not_found", "kullanıcının istenen sürümü bulunamadı")
+	ErrUserVersionInvalid       = New("errors.user_version_invalid", "sürüm kaydı geri yüklenebilecek alanları içermiyor")
+	ErrRestoreConflict          = New("errors.restore_conflict", "kayıt geri yüklenemedi: tekil bir alan başka bir kayıtta kullanılıyor")
+	ErrUserRestoreConflict      = New("errors.user_restore_conflict", "bu hesap adı başka bir kullanıcı tarafından kullanılıyor")
+	ErrUserRestoreFailed        = New("errors.user_restore_failed", "kullanıcı geri yüklenirken bir veritabanı hatası oluştu")
+	ErrUserPurgeFailed          = New("errors.user_purge_failed", "kullanıcı kalıcı olarak silinirken bir veritabanı hatası oluştu")
+	ErrUserBulkEmpty            = New("errors.user_bulk_empty", "toplu işlem için en az bir kullanıcı seçilmelidir")
+	ErrUserBulkInvalidAction    = New("errors.user_bulk_invalid_action", "geçersiz toplu işlem")
+	ErrUserBulkInvalidType      = New("errors.user_bulk_invalid_type", "geçersiz kullanıcı tipi")
+	ErrUserBulkSelf             = New("errors.user_bulk_self", "bu işlem kendi hesabınıza uygulanamaz")
+	ErrUserBulkFailed           = New("errors.user_bulk_failed", "toplu işlem sırasında bir veritabanı hatası oluştu")
+	ErrUserImportEmpty          = New("errors.user_import_empty", "içe aktarılacak satır bulunamadı")
+	ErrUserImportFailed         = New("errors.user_import_failed", "içe aktarma sırasında bir veritabanı hatası oluştu")
+
+	ErrUserListFailed           = New("errors.user_list_failed", "kullanıcılar getirilirken bir hata oluştu")
+	ErrUserTrashListFailed      = New("errors.user_trash_list_failed", "silinen kullanıcılar getirilirken bir hata oluştu")
+	ErrUserFetchFailed          = New("errors.user_fetch_failed", "kullanıcı bilgileri alınırken bir veritabanı hatası oluştu")
+	ErrUserHistoryFailed        = New("errors.user_history_failed", "kullanıcı sürüm geçmişi alınırken bir hata oluştu")
+	ErrUserVersionFetchFailed   = New("errors.user_version_fetch_failed", "kullanıcı sürümü alınırken bir veritabanı hatası oluştu")
+	ErrUserCountFailed          = New("errors.user_count_failed", "kullanıcı sayısı alınırken bir hata oluştu")
+	ErrAuditLogListFailed       = New("errors.audit_log_list_failed", "audit kayıtları getirilirken bir hata oluştu")
+	ErrUserUpdatePrecheckFailed = New("errors.user_update_precheck_failed", "kullanıcı güncellenirken bir veritabanı hatası oluştu (ön kontrol)")
+)
+
+// İçe aktarma satırlarının doğrulama hataları; yer tutucular With ile doldurulur.
 var (
-	ErrRepoRecordNotFound       = errors.New("kayıt bulunamadı")
-	ErrInvalidUserContext       = errors.New("geçersiz kullanıcı context bilgisi")
-	ErrUserServiceUserNotFound  = errors.New("kullanıcı bulunamadı")
-	ErrPasswordHashingFailed    = errors.New("şifre oluşturulurken bir hata oluştu")
-	ErrPasswordUpdateFailed     = errors.New("şifre güncellenirken bir hata oluştu")
-	ErrUserCreationFailed       = errors.New("kullanıcı veritabanına kaydedilemedi")
-	ErrUserUpdateFailed         = errors.New("kullanıcı veritabanında güncellenemedi")
-	ErrUserDeletionFailed       = errors.New("kullanıcı silinirken bir veritabanı hatası oluştu")
-	ErrPasswordRequired         = errors.New("şifre alanı boş olamaz")
-	ErrContextUserIDNotFound    = errors.New("işlemi yapan kullanıcı kimliği context içinde bulunamadı")
-	ErrInvalidCredentials       = errors.New("geçersiz kimlik bilgileri")
-	ErrUserNotFound             = errors.New("kullanıcı bulunamadı")
-	ErrUserInactive             = errors.New("kullanıcı aktif değil")
-	ErrCurrentPasswordIncorrect = errors.New("mevcut şifre hatalı")
-	ErrPasswordTooShort         = errors.New("yeni şifre en az 6 karakter olmalıdır")
-	ErrPasswordSameAsOld        = errors.New("yeni şifre mevcut şifre ile aynı olamaz")
-	ErrAuthGeneric              = errors.New("kimlik doğrulaması sırasında bir hata oluştu")
-	ErrProfileGeneric           = errors.New("profil bilgileri alınırken hata")
-	ErrUpdatePasswordGeneric    = errors.New("şifre güncellenirken bir hata oluştu")
-	ErrHashingFailed            = errors.New("yeni şifre oluşturulurken hata")
-	ErrDatabaseUpdateFailed     = errors.New("veritabanı güncellemesi başarısız oldu")
+	ErrImportNameRequired      = New("errors.import.name_required", "Ad Soyad boş olamaz")
+	ErrImportNameTooLong       = New("errors.import.name_too_long", "Ad Soyad en fazla %d karakter olabilir")
+	ErrImportAccountRequired   = New("errors.import.account_required", "Hesap adı boş olamaz")
+	ErrImportAccountTooLong    = New("errors.import.account_too_long", "Hesap adı en fazla %d karakter olabilir")
+	ErrImportAccountDuplicate  = New("errors.import.account_duplicate", "Hesap adı dosyada %d. satırda da var")
+	ErrImportAccountExists     = New("errors.import.account_exists", "Bu hesap adı zaten kullanılıyor")
+	ErrImportPasswordRequired  = New("errors.import.password_required", "Şifre boş olamaz")
+	ErrImportPasswordTooShort  = New("errors.import.password_too_short", "Şifre en az %d karakter olmalıdır")
+	ErrImportPasswordTooLong   = New("errors.import.password_too_long", "Şifre en fazla %d bayt olabilir")
+	ErrImportInvalidUserType   = New("errors.import.invalid_type", "Geçersiz kullanıcı tipi: %q (dashboard veya panel olmalı)")
+	ErrImportInvalidUserStatus = New("errors.import.invalid_status", "Geçersiz durum: %q (aktif veya pasif olmalı)")
 )
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type exportRow struct {
+	Name    string
+	Count   int
+	Active  bool
+	Created time.Time
+	Deleted *time.Time
+}
+
+var exportColumns = []Column[exportRow]{
+	{Key: "name", Label: "Ad", Value: func(r *exportRow) interface{} { return r.Name }},
+	{Key: "count", Label: "Sayı", Value: func(r *exportRow) interface{} { return r.Count }},
+	{Key: "active", Label: "Aktif", Value: func(r *exportRow) interface{} { return r.Active }},
+	{Key: "created", Label: "Oluşturma", Value: func(r *exportRow) interface{} { return r.Created }},
+	{Key: "deleted", Label: "Silinme", Value: func(r *exportRow) interface{} { return r.Deleted }},
+}
+
+func rowsOf(rows []exportRow) func(ctx context.Context, fn func(item *exportRow) error) error {
+	return func(_ context.Context, fn func(item *exportRow) error) error {
+		for i := range rows {
+			if err := fn(&rows[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 5, 0, 0, time.UTC)
+	rows := []exportRow{
+		{Name: "Çağla, \"Ş\"", Count: -5, Active: true, Created: created, Deleted: &created},
+		{Name: "=HYPERLINK(\"http://x\")", Count: 0},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(context.Background(), &buf, CSV, "", exportColumns, rowsOf(rows)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), utf8BOM) {
+		t.Fatalf("CSV UTF-8 BOM ile başlamıyor: %q", buf.String()[:min(buf.Len(), 8)])
+	}
+	if strings.Count(buf.String(), utf8BOM) != 1 {
+		t.Error("BOM birden fazla yazıldı")
+	}
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), utf8BOM))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"Ad", "Sayı", "Aktif", "Oluşturma", "Silinme"},
+		{"Çağla, \"Ş\"", "-5", "true", "2024-03-01 09:05:00", "2024-03-01 09:05:00"},
+		{"'=HYPERLINK(\"http://x\")", "0", "false", "", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV:\n got  %q\n want %q", records, want)
+	}
+}
+
+func TestCSVFormulaEscaping(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{"=1+1", "'=1+1"},
+		{"+905551112233", "'+905551112233"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"normal", "normal"},
+		{"a=1", "a=1"},
+		{" =1", " =1"},
+		{"", ""},
+		{-42, "-42"},
+		{-1.5, "-1.5"},
+		{formulaStringer("=cmd"), "'=cmd"},
+		{nil, ""},
+		{(*time.Time)(nil), ""},
+		{time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		if got := csvValue(tt.in); got != tt.want {
+			t.Errorf("csvValue(%#v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+type formulaStringer string
+
+func (s formulaStringer) String() string { return string(s) }
+
+func TestWriteStopsOnError(t *testing.T) {
+	var buf bytes.Buffer
+	errStop := context.Canceled
+	err := Write(context.Background(), &buf, CSV, "", exportColumns, func(context.Context, func(*exportRow) error) error {
+		return errStop
+	})
+	if err != errStop {
+		t.Errorf("Write hatası = %v, want %v", err, errStop)
+	}
+}
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Timeout bir dışa aktarmanın toplam süre sınırıdır. Dışa aktarma yanıt
+// gövdesi yazılırken sürdüğü için istek başına sorgu süresi yerine bu kullanılır.
+const Timeout = 10 * time.Minute
+
+const timeLayout = "2006-01-02 15:04:05"
+
+var ErrUnknownFormat = errors.New("desteklenmeyen dışa aktarma biçimi")
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// ParseFormat boş değer için CSV döner.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Filename name'e dışa aktarma zamanını ve biçimin uzantısını ekler.
+func (f Format) Filename(name string, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", name, now.Format("20060102-150405"), f)
+}
+
+// Column dışa aktarılabilen bir sütundur. Value sayı, bool, string veya
+// time.Time dönebilir; XLSX'te sayılar hücreye sayı olarak yazılır.
+type Column[T any] struct {
+	Key   string
+	Label string
+	Value func(item *T) interface{}
+}
+
+// SelectColumns keys'teki sütunları keys sırasıyla döner; bilinmeyen anahtarlar
+// yok sayılır. keys boşsa veya hiçbiri bilinmiyorsa tüm sütunlar döner.
+func SelectColumns[T any](all []Column[T], keys []string) []Column[T] {
+	byKey := make(map[string]Column[T], len(all))
+	for _, column := range all {
+		byKey[column.Key] = column
+	}
+
+	var selected []Column[T]
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		column, ok := byKey[key]
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		selected = append(selected, column)
+	}
+	if len(selected) == 0 {
+		return all
+	}
+	return selected
+}
+
+// RowWriter satırları sırayla hedefe yazar. Close yazılmamış veriyi boşaltır;
+// XLSX'te dosyanın asıl yazımı Close'da olur.
+type RowWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+func NewWriter(format Format, w io.Writer, sheet string) (RowWriter, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w)
+	case XLSX:
+		return newXLSXWriter(w, sheet)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Write başlık satırını ve each'in verdiği her kaydı columns'a göre yazar.
+// each kayıtları bir imleçten okuyup fn'e tek tek vermelidir; böylece liste
+// belleğe alınmadan yazılır.
+func Write[T any](ctx context.Context, w io.Writer, format Format, sheet string, columns []Column[T], each func(ctx context.Context, fn func(item *T) error) error) error {
+	writer, err := NewWriter(format, w, sheet)
+	if err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.Label
+	}
+	if err := writer.WriteRow(header); err != nil {
+		_ = writer.Close()
+		return err
+	}
+
+	row := make([]interface{}, len(columns))
+	err = each(ctx, func(item *T) error {
+		for i, column := range columns {
+			row[i] = column.Value(item)
+		}
+		return writer.WriteRow(row)
+	})
+	if err != nil {
+		_ = writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(timeLayout)
+}
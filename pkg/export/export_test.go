@@ -0,0 +1,74 @@
+package export
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Format
+		err  error
+	}{
+		{"", CSV, nil},
+		{"csv", CSV, nil},
+		{" XLSX ", XLSX, nil},
+		{"pdf", "", ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.in)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestFilename(t *testing.T) {
+	now := time.Date(2024, 12, 31, 23, 5, 9, 0, time.UTC)
+	if got := XLSX.Filename("users", now); got != "users-20241231-230509.xlsx" {
+		t.Errorf("Filename = %q", got)
+	}
+	if got := CSV.ContentType(); got != "text/csv; charset=utf-8" {
+		t.Errorf("ContentType = %q", got)
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	keysOf := func(columns []Column[exportRow]) []string {
+		keys := make([]string, len(columns))
+		for i, column := range columns {
+			keys[i] = column.Key
+		}
+		return keys
+	}
+	tests := []struct {
+		keys []string
+		want []string
+	}{
+		{[]string{"count", "name"}, []string{"count", "name"}},
+		{[]string{"name", "password", "name"}, []string{"name"}},
+		{[]string{"password"}, []string{"name", "count", "active", "created", "deleted"}},
+		{nil, []string{"name", "count", "active", "created", "deleted"}},
+	}
+	for _, tt := range tests {
+		got := keysOf(SelectColumns(exportColumns, tt.keys))
+		if len(got) != len(tt.want) {
+			t.Errorf("SelectColumns(%q) = %q, want %q", tt.keys, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("SelectColumns(%q) = %q, want %q", tt.keys, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	if _, err := NewWriter("pdf", nil, ""); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("NewWriter hatası = %v", err)
+	}
+}
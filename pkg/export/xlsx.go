@@ -0,0 +1,92 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrTooManyRows XLSX'in bir sayfada izin verdiği satır sayısı aşıldığında döner.
+var ErrTooManyRows = errors.New("dışa aktarılacak kayıt sayısı XLSX satır sınırını aşıyor, CSV kullanın")
+
+// xlsxWriter satırları excelize'in StreamWriter'ı ile yazar; büyük sayfalar
+// bellekte değil geçici dosyada tutulur.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	header int
+}
+
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	header, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{out: w, file: file, stream: stream, header: header}, nil
+}
+
+func (x *xlsxWriter) WriteRow(values []interface{}) error {
+	if x.row >= excelize.TotalRows {
+		return ErrTooManyRows
+	}
+	x.row++
+
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		value = xlsxValue(value)
+		if x.row == 1 {
+			value = excelize.Cell{StyleID: x.header, Value: value}
+		}
+		cells[i] = value
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
+
+// xlsxValue tarihleri CSV ile aynı biçimde metne çevirir; diğer değerler
+// excelize'in tür eşlemesine bırakılır.
+func xlsxValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return formatTime(v)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return formatTime(*v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 5, 0, 0, time.UTC)
+	rows := []exportRow{
+		{Name: "İpek Şahin", Count: 42, Active: true, Created: created},
+		{Name: "=1+1", Count: -3},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(context.Background(), &buf, XLSX, "Kullanıcılar", exportColumns, rowsOf(rows)); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if sheets := file.GetSheetList(); !reflect.DeepEqual(sheets, []string{"Kullanıcılar"}) {
+		t.Errorf("sayfalar = %q", sheets)
+	}
+	got, err := file.GetRows("Kullanıcılar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"Ad", "Sayı", "Aktif", "Oluşturma", "Silinme"},
+		{"İpek Şahin", "42", "TRUE", "2024-03-01 09:05:00"},
+		{"=1+1", "-3", "FALSE"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("satırlar:\n got  %q\n want %q", got, want)
+	}
+
+	for _, cell := range []string{"A1", "E1"} {
+		styleID, err := file.GetCellStyle("Kullanıcılar", cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		style, err := file.GetStyle(styleID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if style.Font == nil || !style.Font.Bold {
+			t.Errorf("%s başlık hücresi kalın değil", cell)
+		}
+	}
+	if styleID, _ := file.GetCellStyle("Kullanıcılar", "A2"); styleID != 0 {
+		t.Errorf("veri hücresi başlık stilinde: %d", styleID)
+	}
+	if formula, _ := file.GetCellFormula("Kullanıcılar", "A3"); formula != "" {
+		t.Errorf("metin formül olarak yazıldı: %q", formula)
+	}
+}
+
+func TestXLSXDefaultSheet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(context.Background(), &buf, XLSX, "", exportColumns[:1], rowsOf(nil)); err != nil {
+		t.Fatal(err)
+	}
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if rows, _ := file.GetRows("Sheet1"); !reflect.DeepEqual(rows, [][]string{{"Ad"}}) {
+		t.Errorf("satırlar = %q", rows)
+	}
+}
@@ -0,0 +1,144 @@
+package flashmessages
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Level mesajın önem derecesidir; değerler SweetAlert2 ikon adlarıyla
+// aynıdır.
+type Level string
+
+const (
+	LevelSuccess Level = "success"
+	LevelInfo    Level = "info"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+// Message kullanıcıya bir sonraki sayfada gösterilecek tek bir bildirimdir.
+// Dismissible mesaj kullanıcı kapatana kadar ekranda kalır; diğerleri kısa
+// bir süre sonra kendiliğinden kapanır. Title boşsa arayüz seviyenin
+// varsayılan başlığını kullanır.
+type Message struct {
+	Level       Level  `json:"level"`
+	Title       string `json:"title,omitempty"`
+	Text        string `json:"text"`
+	Dismissible bool   `json:"dismissible"`
+}
+
+// Bag bir yönlendirmeden sonraki isteğe taşınan flash verisidir: mesajlar,
+// formun eski değerleri ve alan hataları. Şablonlarda .Flash ile erişilir;
+// metotları nil Bag üzerinde de çalışır.
+type Bag struct {
+	Messages    []Message         `json:"messages,omitempty"`
+	OldInput    map[string]string `json:"old_input,omitempty"`
+	FieldErrors map[string]string `json:"field_errors,omitempty"`
+}
+
+const (
+	// maxMessages bir istekte biriktirilebilecek mesaj sayısıdır; döngüde
+	// flash eklenen hatalı bir akış oturumu şişirmesin.
+	maxMessages = 10
+	// maxInputLength eski form değerlerinin saklanacak en fazla uzunluğudur.
+	maxInputLength = 1024
+)
+
+// Add mesajı ekler; aynı seviye ve metinle eklenmiş bir mesaj tekrar
+// eklenmez.
+func (b *Bag) Add(msg Message) {
+	if msg.Text == "" || len(b.Messages) >= maxMessages {
+		return
+	}
+	for _, existing := range b.Messages {
+		if existing.Level == msg.Level && existing.Text == msg.Text {
+			return
+		}
+	}
+	b.Messages = append(b.Messages, msg)
+}
+
+// SetInput formun eski değerini saklar. Şifre ve CSRF alanları hiçbir zaman
+// saklanmaz.
+func (b *Bag) SetInput(field, value string) {
+	if !keepInput(field) {
+		return
+	}
+	if len(value) > maxInputLength {
+		n := maxInputLength
+		for n > 0 && !utf8.RuneStart(value[n]) {
+			n--
+		}
+		value = value[:n]
+	}
+	if b.OldInput == nil {
+		b.OldInput = make(map[string]string)
+	}
+	b.OldInput[field] = value
+}
+
+// SetFieldError alanın yanında gösterilecek hatayı ayarlar.
+func (b *Bag) SetFieldError(field, message string) {
+	if b.FieldErrors == nil {
+		b.FieldErrors = make(map[string]string)
+	}
+	b.FieldErrors[field] = message
+}
+
+// Merge other'daki verileri b'ye ekler; aynı alan için other'ın değeri
+// geçerlidir.
+func (b *Bag) Merge(other *Bag) {
+	if other == nil {
+		return
+	}
+	for _, msg := range other.Messages {
+		b.Add(msg)
+	}
+	for field, value := range other.OldInput {
+		b.SetInput(field, value)
+	}
+	for field, message := range other.FieldErrors {
+		b.SetFieldError(field, message)
+	}
+}
+
+// Empty taşınacak hiçbir veri yoksa true döner.
+func (b *Bag) Empty() bool {
+	return b == nil || (len(b.Messages) == 0 && len(b.OldInput) == 0 && len(b.FieldErrors) == 0)
+}
+
+// HasErrors hata seviyesinde bir mesaj veya alan hatası varsa true döner.
+func (b *Bag) HasErrors() bool {
+	if b == nil {
+		return false
+	}
+	for _, msg := range b.Messages {
+		if msg.Level == LevelError {
+			return true
+		}
+	}
+	return len(b.FieldErrors) > 0
+}
+
+// Old alanın yönlendirmeden önce gönderilmiş değerini döner:
+//
+//	value="{{.Flash.Old "account"}}"
+func (b *Bag) Old(field string) string {
+	if b == nil {
+		return ""
+	}
+	return b.OldInput[field]
+}
+
+// FieldError alanın hatasını döner; hata yoksa boş döner.
+func (b *Bag) FieldError(field string) string {
+	if b == nil {
+		return ""
+	}
+	return b.FieldErrors[field]
+}
+
+func keepInput(field string) bool {
+	field = strings.ToLower(field)
+	return field != "" && field != "csrf_token" && !strings.Contains(field, "password")
+}
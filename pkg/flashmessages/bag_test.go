@@ -0,0 +1,118 @@
+package flashmessages
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBagAdd(t *testing.T) {
+	var bag Bag
+	bag.Add(Message{Level: LevelError, Text: "Kayıt bulunamadı"})
+	bag.Add(Message{Level: LevelSuccess, Text: "Kaydedildi"})
+	bag.Add(Message{Level: LevelError, Text: "Kayıt bulunamadı", Dismissible: true})
+	bag.Add(Message{Level: LevelWarning, Text: "Kayıt bulunamadı"})
+	bag.Add(Message{Level: LevelInfo, Text: ""})
+	bag.Add(Message{Level: LevelInfo, Title: "Bilgi", Text: "Liste yenilendi"})
+
+	want := []Message{
+		{Level: LevelError, Text: "Kayıt bulunamadı"},
+		{Level: LevelSuccess, Text: "Kaydedildi"},
+		{Level: LevelWarning, Text: "Kayıt bulunamadı"},
+		{Level: LevelInfo, Title: "Bilgi", Text: "Liste yenilendi"},
+	}
+	if !reflect.DeepEqual(bag.Messages, want) {
+		t.Errorf("Messages:\n got  %+v\n want %+v", bag.Messages, want)
+	}
+}
+
+func TestBagAddLimit(t *testing.T) {
+	var bag Bag
+	for i := 0; i < maxMessages+5; i++ {
+		bag.Add(Message{Level: LevelInfo, Text: "mesaj " + strconv.Itoa(i)})
+	}
+	if len(bag.Messages) != maxMessages {
+		t.Fatalf("%d mesaj saklandı, en fazla %d bekleniyordu", len(bag.Messages), maxMessages)
+	}
+	if last := bag.Messages[maxMessages-1].Text; last != "mesaj "+strconv.Itoa(maxMessages-1) {
+		t.Errorf("son mesaj = %q, ilk eklenenler korunmalı", last)
+	}
+}
+
+func TestBagSetInput(t *testing.T) {
+	var bag Bag
+	bag.SetInput("account", "ayşe")
+	bag.SetInput("password", "gizli")
+	bag.SetInput("New_Password", "gizli")
+	bag.SetInput("csrf_token", "token")
+	bag.SetInput("", "boş")
+	long := strings.Repeat("a", maxInputLength-1) + "ş"
+	bag.SetInput("note", long)
+
+	want := map[string]string{
+		"account": "ayşe",
+		"note":    strings.Repeat("a", maxInputLength-1),
+	}
+	if !reflect.DeepEqual(bag.OldInput, want) {
+		t.Errorf("OldInput = %q", bag.OldInput)
+	}
+	if got := bag.Old("account"); got != "ayşe" {
+		t.Errorf("Old(account) = %q", got)
+	}
+}
+
+func TestBagMerge(t *testing.T) {
+	bag := &Bag{Messages: []Message{{Level: LevelSuccess, Text: "Önceki"}}, OldInput: map[string]string{"name": "eski"}}
+	bag.Merge(&Bag{
+		Messages:    []Message{{Level: LevelSuccess, Text: "Önceki"}, {Level: LevelError, Text: "Yeni"}},
+		OldInput:    map[string]string{"name": "yeni", "password": "gizli"},
+		FieldErrors: map[string]string{"name": "zorunlu"},
+	})
+	bag.Merge(nil)
+
+	want := &Bag{
+		Messages:    []Message{{Level: LevelSuccess, Text: "Önceki"}, {Level: LevelError, Text: "Yeni"}},
+		OldInput:    map[string]string{"name": "yeni"},
+		FieldErrors: map[string]string{"name": "zorunlu"},
+	}
+	if !reflect.DeepEqual(bag, want) {
+		t.Errorf("Merge:\n got  %+v\n want %+v", bag, want)
+	}
+}
+
+func TestBagState(t *testing.T) {
+	var nilBag *Bag
+	if !nilBag.Empty() || nilBag.HasErrors() || nilBag.Old("x") != "" || nilBag.FieldError("x") != "" {
+		t.Error("nil Bag boş davranmalı")
+	}
+
+	tests := []struct {
+		bag              Bag
+		empty, hasErrors bool
+	}{
+		{Bag{}, true, false},
+		{Bag{Messages: []Message{{Level: LevelWarning, Text: "x"}}}, false, false},
+		{Bag{Messages: []Message{{Level: LevelError, Text: "x"}}}, false, true},
+		{Bag{OldInput: map[string]string{"a": "b"}}, false, false},
+		{Bag{FieldErrors: map[string]string{"a": "b"}}, false, true},
+	}
+	for i, tt := range tests {
+		if tt.bag.Empty() != tt.empty || tt.bag.HasErrors() != tt.hasErrors {
+			t.Errorf("%d: Empty/HasErrors = %v/%v, want %v/%v", i, tt.bag.Empty(), tt.bag.HasErrors(), tt.empty, tt.hasErrors)
+		}
+	}
+}
+
+func TestBagJSON(t *testing.T) {
+	bag := &Bag{Messages: []Message{{Level: LevelSuccess, Text: "Tamam"}}}
+	data, err := json.Marshal(Response{Redirect: "/dashboard/users", Bag: bag})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"redirect":"/dashboard/users","messages":[{"level":"success","text":"Tamam","dismissible":false}]}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
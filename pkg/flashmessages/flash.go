@@ -1,6 +1,7 @@
 package flashmessages
 
 import (
+	"encoding/json"
 	"zatrano/pkg/logs"
 	"zatrano/pkg/sessions"
 
@@ -20,22 +21,133 @@ const (
 )
 
 const (
-	FlashSuccessKey = "flash_success_message"
-	FlashErrorKey   = "flash_error_message"
+	// sessionKey altında Bag tek bir JSON metni olarak saklanır; böylece
+	// session'a ek gob türü kaydetmek gerekmez ve her istek en fazla bir
+	// kez yazılır.
+	sessionKey = "flash"
+	localsKey  = "flash_pending"
 )
 
-type FlashMessagesData struct {
-	Success string
-	Error   string
+// Response XHR isteklerine yönlendirme yerine dönen JSON'dur:
+//
+//	{"redirect": "/dashboard/users", "messages": [{"level": "success", "text": "...", "dismissible": false}]}
+type Response struct {
+	Redirect string `json:"redirect,omitempty"`
+	*Bag
 }
 
-func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
+// Add mesajı isteğin flash'ına ekler. Mesajlar istek bitince Middleware
+// tarafından session'a yazılır; handler aynı istekte sayfa render ederse
+// session'a hiç yazılmadan o sayfada gösterilir.
+func Add(c *fiber.Ctx, msg Message) {
+	pending(c).Add(msg)
+}
+
+// Success ve Info kısa süre sonra kendiliğinden kapanan, Warning ve Error
+// kullanıcı kapatana kadar kalan bir mesaj ekler.
+func Success(c *fiber.Ctx, text string) {
+	Add(c, Message{Level: LevelSuccess, Text: text})
+}
+
+func Info(c *fiber.Ctx, text string) {
+	Add(c, Message{Level: LevelInfo, Text: text})
+}
+
+func Warning(c *fiber.Ctx, text string) {
+	Add(c, Message{Level: LevelWarning, Text: text, Dismissible: true})
+}
+
+func Error(c *fiber.Ctx, text string) {
+	Add(c, Message{Level: LevelError, Text: text, Dismissible: true})
+}
+
+// KeepInput isteğin form alanlarını yönlendirilen sayfada formu yeniden
+// doldurmak için saklar (bkz. Bag.Old).
+func KeepInput(c *fiber.Ctx) {
+	bag := pending(c)
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		bag.SetInput(string(key), string(value))
+	})
+}
+
+// SetFieldError alanın yanında gösterilecek bir hata ekler.
+func SetFieldError(c *fiber.Ctx, field, message string) {
+	pending(c).SetFieldError(field, message)
+}
+
+// Pop önceki istekten session'da kalan ve bu istekte eklenen flash
+// verilerini birlikte döner ve ikisini de temizler. Hata olsa bile
+// okunabilen veriler döner.
+func Pop(c *fiber.Ctx) (*Bag, error) {
+	bag := &Bag{}
+	sess, err := sessions.SessionStart(c)
+	if err != nil {
+		logs.Log.Error("Flash mesajları alınırken session başlatılamadı", zap.Error(err))
+		bag.Merge(takePending(c))
+		return bag, ErrSessionStartFailed
+	}
+
+	var popErr error
+	if raw, ok := sess.Get(sessionKey).(string); ok {
+		bag.Merge(decode(raw))
+		sess.Delete(sessionKey)
+		if err := sess.Save(); err != nil {
+			logs.Log.Error("Flash mesajları alındıktan sonra session kaydedilemedi", zap.Error(err))
+			popErr = ErrSessionSaveFailed
+		}
+	}
+	bag.Merge(takePending(c))
+	return bag, popErr
+}
+
+// IsXHR isteğin sayfa yerine JSON bekleyen bir fetch/XHR isteği olup
+// olmadığını döner.
+func IsXHR(c *fiber.Ctx) bool {
+	return c.XHR() || c.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON
+}
+
+// Middleware istek boyunca eklenen flash verilerini handler döndükten sonra
+// session'a tek seferde yazar. XHR isteklerinde yönlendirme yerine hedef
+// adres ve flash verileri JSON olarak döner (bkz. Response); istemci
+// mesajları gösterip yönlendirmeye kendisi karar verir. Flash ekleyen
+// middleware'lerden (ör. CSRF) önce kayıtlı olmalıdır.
+func Middleware(c *fiber.Ctx) error {
+	err := c.Next()
+
+	if IsXHR(c) && isRedirect(c) {
+		location := string(c.Response().Header.Peek(fiber.HeaderLocation))
+		bag, _ := Pop(c)
+		c.Response().Header.Del(fiber.HeaderLocation)
+		if jsonErr := c.Status(fiber.StatusOK).JSON(Response{Redirect: location, Bag: bag}); jsonErr != nil {
+			return jsonErr
+		}
+		return err
+	}
+
+	if bag := takePending(c); !bag.Empty() {
+		_ = save(c, bag)
+	}
+	return err
+}
+
+func save(c *fiber.Ctx, bag *Bag) error {
 	sess, err := sessions.SessionStart(c)
 	if err != nil {
 		logs.Log.Error("Flash mesajı için session başlatılamadı", zap.Error(err))
 		return ErrSessionStartFailed
 	}
-	sess.Set(key, message)
+	// Önceki istekten okunmamış veriler varsa korunur.
+	if raw, ok := sess.Get(sessionKey).(string); ok {
+		stored := decode(raw)
+		stored.Merge(bag)
+		bag = stored
+	}
+	data, err := json.Marshal(bag)
+	if err != nil {
+		logs.Log.Error("Flash mesajı kodlanamadı", zap.Error(err))
+		return ErrSessionSaveFailed
+	}
+	sess.Set(sessionKey, string(data))
 	if err := sess.Save(); err != nil {
 		logs.Log.Error("Flash mesajı için session kaydedilemedi", zap.Error(err))
 		return ErrSessionSaveFailed
@@ -43,38 +155,32 @@ func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
 	return nil
 }
 
-func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
-	messages := FlashMessagesData{}
-	sess, err := sessions.SessionStart(c)
-	if err != nil {
-		logs.Log.Error("Flash mesajları alınırken session başlatılamadı", zap.Error(err))
-		return messages, ErrSessionStartFailed
-	}
-
-	var sessionNeedsSave bool
-
-	if success := sess.Get(FlashSuccessKey); success != nil {
-		if msg, ok := success.(string); ok {
-			messages.Success = msg
-			sess.Delete(FlashSuccessKey)
-			sessionNeedsSave = true
-		}
+func decode(raw string) *Bag {
+	bag := &Bag{}
+	if err := json.Unmarshal([]byte(raw), bag); err != nil {
+		logs.Log.Warn("Session'daki flash verisi çözülemedi", zap.Error(err))
+		return &Bag{}
 	}
+	return bag
+}
 
-	if errorFlash := sess.Get(FlashErrorKey); errorFlash != nil {
-		if msg, ok := errorFlash.(string); ok {
-			messages.Error = msg
-			sess.Delete(FlashErrorKey)
-			sessionNeedsSave = true
-		}
+func pending(c *fiber.Ctx) *Bag {
+	if bag, ok := c.Locals(localsKey).(*Bag); ok {
+		return bag
 	}
+	bag := &Bag{}
+	c.Locals(localsKey, bag)
+	return bag
+}
 
-	if sessionNeedsSave {
-		if err := sess.Save(); err != nil {
-			logs.Log.Error("Flash mesajları alındıktan sonra session kaydedilemedi", zap.Error(err))
-			return messages, ErrSessionSaveFailed
-		}
-	}
+func takePending(c *fiber.Ctx) *Bag {
+	bag, _ := c.Locals(localsKey).(*Bag)
+	c.Locals(localsKey, nil)
+	return bag
+}
 
-	return messages, nil
+func isRedirect(c *fiber.Ctx) bool {
+	status := c.Response().StatusCode()
+	return status >= fiber.StatusMultipleChoices && status < fiber.StatusBadRequest &&
+		len(c.Response().Header.Peek(fiber.HeaderLocation)) > 0
 }
@@ -0,0 +1,195 @@
+package flashmessages
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/sessions"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logs.Log = zap.NewNop()
+	logs.SLog = logs.Log.Sugar()
+}
+
+// popResult /show'un döndüğü JSON'dur.
+type popResult struct {
+	Bag   *Bag   `json:"bag"`
+	Error string `json:"error"`
+}
+
+func newFlashApp(withSession bool) *fiber.App {
+	app := fiber.New()
+	if withSession {
+		app.Use(sessions.Middleware(session.New()))
+	}
+	app.Use(Middleware)
+
+	app.Post("/save", func(c *fiber.Ctx) error {
+		Error(c, "Kayıt bulunamadı")
+		Success(c, "Kaydedildi")
+		Info(c, "Liste yenilendi")
+		Warning(c, "Bazı satırlar atlandı")
+		KeepInput(c)
+		SetFieldError(c, "account", "Hesap adı zorunlu")
+		return c.Redirect("/show", fiber.StatusSeeOther)
+	})
+	app.Post("/again", func(c *fiber.Ctx) error {
+		Success(c, "Tekrar kaydedildi")
+		return c.Redirect("/show", fiber.StatusSeeOther)
+	})
+	app.Get("/render", func(c *fiber.Ctx) error {
+		Error(c, "Aynı istekte gösterilir")
+		return show(c)
+	})
+	app.Get("/show", show)
+	return app
+}
+
+func show(c *fiber.Ctx) error {
+	bag, err := Pop(c)
+	result := popResult{Bag: bag}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return c.JSON(result)
+}
+
+type flashClient struct {
+	t       *testing.T
+	app     *fiber.App
+	cookies []*http.Cookie
+}
+
+func (fc *flashClient) do(req *http.Request) (*http.Response, []byte) {
+	fc.t.Helper()
+	for _, cookie := range fc.cookies {
+		req.AddCookie(cookie)
+	}
+	resp, err := fc.app.Test(req)
+	if err != nil {
+		fc.t.Fatal(err)
+	}
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		fc.cookies = cookies
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp, body
+}
+
+func (fc *flashClient) post(path, form string) *http.Response {
+	fc.t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(form))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+	resp, _ := fc.do(req)
+	return resp
+}
+
+func (fc *flashClient) show(path string) popResult {
+	fc.t.Helper()
+	_, body := fc.do(httptest.NewRequest(fiber.MethodGet, path, nil))
+	var result popResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		fc.t.Fatalf("%s yanıtı çözülemedi: %v: %s", path, err, body)
+	}
+	return result
+}
+
+func TestFlashConsumedOnce(t *testing.T) {
+	client := &flashClient{t: t, app: newFlashApp(true)}
+
+	resp := client.post("/save", "account=ayse&password=gizli&csrf_token=x")
+	if resp.StatusCode != fiber.StatusSeeOther {
+		t.Fatalf("durum = %d", resp.StatusCode)
+	}
+
+	got := client.show("/show")
+	want := &Bag{
+		Messages: []Message{
+			{Level: LevelError, Text: "Kayıt bulunamadı", Dismissible: true},
+			{Level: LevelSuccess, Text: "Kaydedildi"},
+			{Level: LevelInfo, Text: "Liste yenilendi"},
+			{Level: LevelWarning, Text: "Bazı satırlar atlandı", Dismissible: true},
+		},
+		OldInput:    map[string]string{"account": "ayse"},
+		FieldErrors: map[string]string{"account": "Hesap adı zorunlu"},
+	}
+	if got.Error != "" || !reflect.DeepEqual(got.Bag, want) {
+		t.Errorf("ilk okuma:\n got  %+v (%s)\n want %+v", got.Bag, got.Error, want)
+	}
+
+	if again := client.show("/show"); !again.Bag.Empty() {
+		t.Errorf("flash ikinci kez okundu: %+v", again.Bag)
+	}
+}
+
+func TestFlashAccumulatesUntilRead(t *testing.T) {
+	client := &flashClient{t: t, app: newFlashApp(true)}
+	client.post("/save", "")
+	client.post("/again", "")
+	client.post("/again", "")
+
+	got := client.show("/show")
+	var texts []string
+	for _, msg := range got.Bag.Messages {
+		texts = append(texts, msg.Text)
+	}
+	want := []string{"Kayıt bulunamadı", "Kaydedildi", "Liste yenilendi", "Bazı satırlar atlandı", "Tekrar kaydedildi"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("mesajlar = %q, want %q", texts, want)
+	}
+}
+
+func TestFlashSameRequest(t *testing.T) {
+	client := &flashClient{t: t, app: newFlashApp(true)}
+	got := client.show("/render")
+	if want := []Message{{Level: LevelError, Text: "Aynı istekte gösterilir", Dismissible: true}}; !reflect.DeepEqual(got.Bag.Messages, want) {
+		t.Errorf("aynı istekteki mesajlar = %+v", got.Bag.Messages)
+	}
+	if next := client.show("/show"); !next.Bag.Empty() {
+		t.Errorf("gösterilen mesaj session'a yazıldı: %+v", next.Bag)
+	}
+}
+
+func TestFlashWithoutSession(t *testing.T) {
+	client := &flashClient{t: t, app: newFlashApp(false)}
+
+	if resp := client.post("/save", "account=ayse"); resp.StatusCode != fiber.StatusSeeOther {
+		t.Errorf("session yokken yönlendirme bozuldu: %d", resp.StatusCode)
+	}
+
+	got := client.show("/render")
+	if got.Error != ErrSessionStartFailed.Error() {
+		t.Errorf("Pop hatası = %q, want %q", got.Error, ErrSessionStartFailed)
+	}
+	if len(got.Bag.Messages) != 1 || got.Bag.Messages[0].Text != "Aynı istekte gösterilir" {
+		t.Errorf("session yokken aynı istekteki mesajlar kayboldu: %+v", got.Bag)
+	}
+}
+
+func TestFlashXHRRedirect(t *testing.T) {
+	client := &flashClient{t: t, app: newFlashApp(true)}
+
+	req := httptest.NewRequest(fiber.MethodPost, "/again", nil)
+	req.Header.Set(fiber.HeaderXRequestedWith, "XMLHttpRequest")
+	resp, body := client.do(req)
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderLocation) != "" {
+		t.Fatalf("durum = %d, Location = %q", resp.StatusCode, resp.Header.Get(fiber.HeaderLocation))
+	}
+	want := `{"redirect":"/show","messages":[{"level":"success","text":"Tekrar kaydedildi","dismissible":false}]}`
+	if string(body) != want {
+		t.Errorf("XHR yanıtı = %s, want %s", body, want)
+	}
+	if next := client.show("/show"); !next.Bag.Empty() {
+		t.Errorf("XHR yanıtındaki mesajlar session'a da yazıldı: %+v", next.Bag)
+	}
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type Check struct {
+	Name string
+	Run  CheckFunc
+}
+
+type CheckResult struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status       string        `json:"status"`
+	ShuttingDown bool          `json:"shutting_down"`
+	StartedAt    time.Time     `json:"started_at"`
+	Uptime       string        `json:"uptime"`
+	Checks       []CheckResult `json:"checks"`
+}
+
+type State struct {
+	startedAt    time.Time
+	ready        atomic.Bool
+	shuttingDown atomic.Bool
+}
+
+func NewState() *State {
+	return &State{startedAt: time.Now()}
+}
+
+func (s *State) MarkReady() {
+	s.ready.Store(true)
+}
+
+func (s *State) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+	s.ready.Store(false)
+}
+
+func (s *State) IsShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+func (s *State) IsReady() bool {
+	return s.ready.Load() && !s.shuttingDown.Load()
+}
+
+func (s *State) RunChecks(ctx context.Context, checks []Check) Report {
+	report := Report{
+		Status:       StatusUp,
+		ShuttingDown: s.IsShuttingDown(),
+		StartedAt:    s.startedAt.UTC(),
+		Uptime:       time.Since(s.startedAt).Round(time.Second).String(),
+		Checks:       make([]CheckResult, 0, len(checks)),
+	}
+
+	if !s.IsReady() {
+		report.Status = StatusDown
+	}
+
+	for _, check := range checks {
+		start := time.Now()
+		err := check.Run(ctx)
+
+		result := CheckResult{
+			Name:       check.Name,
+			Status:     StatusUp,
+			DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if err != nil {
+			result.Status = StatusDown
+			result.Error = err.Error()
+			report.Status = StatusDown
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report
+}
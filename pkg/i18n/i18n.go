@@ -0,0 +1,235 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralForms katalogdaki çoğul anahtarlarını CLDR biçimlerine eşler. Bir
+// nesnenin tüm anahtarları bunlardan biriyse ve "other" varsa nesne iç içe
+// bir grup değil, çoğul biçimli tek bir mesaj kabul edilir:
+//
+//	"deleted": {"one": "%d kullanıcı silindi.", "other": "%d kullanıcı silindi."}
+var pluralForms = map[string]plural.Form{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+// message tek bir çeviridir; çoğul biçimi olmayan mesajlarda yalnızca
+// plural.Other doludur.
+type message map[plural.Form]string
+
+type catalog map[string]message
+
+// Bundle dizinden yüklenmiş dil kataloglarını tutar. Bir dilde bulunmayan
+// anahtar için varsayılan dilin kataloğuna bakılır. location, saat dilimi
+// seçmemiş kullanıcılara tarihlerin gösterildiği saat dilimidir.
+type Bundle struct {
+	fallback language.Tag
+	tags     []language.Tag
+	matcher  language.Matcher
+	catalogs map[language.Tag]catalog
+	location *time.Location
+}
+
+// Load dir içindeki <dil>.json ve <dil>.toml dosyalarını okur (ör. tr.json,
+// en.toml). İç içe nesneler noktayla birleştirilmiş anahtarlara dönüşür:
+// {"users": {"created": "..."}} → "users.created". fallback dilinin kataloğu
+// bulunmalıdır.
+func Load(dir, fallback string) (*Bundle, error) {
+	fallbackTag, err := language.Parse(fallback)
+	if err != nil {
+		return nil, fmt.Errorf("varsayılan dil geçersiz: %q", fallback)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("dil dizini okunamadı: %w", err)
+	}
+
+	catalogs := make(map[language.Tag]catalog)
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+		tag, err := language.Parse(strings.TrimSuffix(entry.Name(), ext))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: dosya adı bir dil kodu değil", entry.Name()))
+			continue
+		}
+		cat, err := loadCatalog(filepath.Join(dir, entry.Name()), ext)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+		if existing, ok := catalogs[tag]; ok {
+			for key, msg := range cat {
+				existing[key] = msg
+			}
+			continue
+		}
+		catalogs[tag] = cat
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if _, ok := catalogs[fallbackTag]; !ok {
+		return nil, fmt.Errorf("varsayılan dil %q için %s içinde katalog bulunamadı", fallback, dir)
+	}
+	return newBundle(fallbackTag, catalogs), nil
+}
+
+func newBundle(fallback language.Tag, catalogs map[language.Tag]catalog) *Bundle {
+	// Matcher eşleşme olmadığında ilk dili döndüğü için varsayılan dil başta
+	// olmalı; diğerleri sabit sıra için alfabetik dizilir.
+	tags := []language.Tag{fallback}
+	for tag := range catalogs {
+		if tag != fallback {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags[1:], func(i, j int) bool { return tags[i+1].String() < tags[j+1].String() })
+
+	return &Bundle{
+		fallback: fallback,
+		tags:     tags,
+		matcher:  language.NewMatcher(tags),
+		catalogs: catalogs,
+		location: time.UTC,
+	}
+}
+
+func loadCatalog(path, ext string) (catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]interface{})
+	if ext == ".toml" {
+		err = toml.Unmarshal(data, &raw)
+	} else {
+		err = json.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cat := make(catalog)
+	if err := flatten("", raw, cat); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+func flatten(prefix string, values map[string]interface{}, cat catalog) error {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			cat[key] = message{plural.Other: v}
+		case map[string]interface{}:
+			if msg, ok := pluralMessage(v); ok {
+				cat[key] = msg
+				continue
+			}
+			if err := flatten(key, v, cat); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: mesaj metin veya nesne olmalı", key)
+		}
+	}
+	return nil
+}
+
+func pluralMessage(values map[string]interface{}) (message, bool) {
+	if _, ok := values["other"]; !ok {
+		return nil, false
+	}
+	msg := make(message, len(values))
+	for key, value := range values {
+		form, ok := pluralForms[key]
+		text, isText := value.(string)
+		if !ok || !isText {
+			return nil, false
+		}
+		msg[form] = text
+	}
+	return msg, true
+}
+
+// Fallback eşleşme bulunamadığında kullanılan varsayılan dildir.
+func (b *Bundle) Fallback() language.Tag {
+	return b.fallback
+}
+
+// Locales yüklenmiş dilleri, varsayılan dil başta olacak şekilde döner.
+func (b *Bundle) Locales() []language.Tag {
+	return append([]language.Tag(nil), b.tags...)
+}
+
+// Match değerleri sırayla dener ve ilk desteklenen dili döner. Her değer
+// tek bir dil kodu ("en") veya Accept-Language başlığı ("en-US,en;q=0.9")
+// olabilir; "en-GB" gibi bölgeli kodlar "en" kataloğuyla eşleşir. Hiçbiri
+// eşleşmezse varsayılan dil ve false döner.
+func (b *Bundle) Match(values ...string) (language.Tag, bool) {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		desired, _, err := language.ParseAcceptLanguage(value)
+		if err != nil || len(desired) == 0 {
+			continue
+		}
+		if _, index, confidence := b.matcher.Match(desired...); confidence != language.No {
+			return b.tags[index], true
+		}
+	}
+	return b.fallback, false
+}
+
+// Location varsayılan saat dilimidir (bkz. SetLocation).
+func (b *Bundle) Location() *time.Location {
+	return b.location
+}
+
+// SetLocation tarihlerin varsayılan olarak gösterileceği saat dilimini
+// ayarlar; Load UTC ile başlar.
+func (b *Bundle) SetLocation(loc *time.Location) {
+	b.location = loc
+}
+
+// Localizer tag dilinde çeviri yapan bir Localizer döner; tag desteklenen
+// bir dil değilse en yakın dil veya varsayılan dil kullanılır.
+func (b *Bundle) Localizer(tag language.Tag) *Localizer {
+	if _, ok := b.catalogs[tag]; !ok {
+		tag, _ = b.Match(tag.String())
+	}
+	return &Localizer{bundle: b, tag: tag, location: b.location}
+}
+
+func (b *Bundle) lookup(tag language.Tag, key string) (message, bool) {
+	if msg, ok := b.catalogs[tag][key]; ok {
+		return msg, true
+	}
+	msg, ok := b.catalogs[b.fallback][key]
+	return msg, ok
+}
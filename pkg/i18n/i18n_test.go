@@ -0,0 +1,213 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+const testTR = `{
+  "language": {"name": "Türkçe"},
+  "format": {"date": "02.01.2006"},
+  "users": {
+    "created": "Kullanıcı oluşturuldu.",
+    "greeting": "Merhaba %s",
+    "deleted": {"one": "%d kullanıcı silindi.", "other": "%d kullanıcı silindi."},
+    "only_tr": "Yalnızca Türkçe"
+  }
+}`
+
+const testEN = `
+[language]
+name = "English"
+
+[format]
+date = "01/02/2006"
+
+[users]
+created = "User created."
+greeting = "Hello %s"
+
+[users.deleted]
+one = "%d user deleted."
+other = "%d users deleted."
+`
+
+func writeCatalogs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func testBundle(t *testing.T, fallback string) *Bundle {
+	t.Helper()
+	dir := writeCatalogs(t, map[string]string{"tr.json": testTR, "en.toml": testEN, "notes.txt": "x"})
+	b, err := Load(dir, fallback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestLoad(t *testing.T) {
+	b := testBundle(t, "tr")
+	if got, want := b.Locales(), []language.Tag{language.Turkish, language.English}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Locales = %v, want %v", got, want)
+	}
+	want := catalog{
+		"language.name":  {plural.Other: "Türkçe"},
+		"format.date":    {plural.Other: "02.01.2006"},
+		"users.created":  {plural.Other: "Kullanıcı oluşturuldu."},
+		"users.greeting": {plural.Other: "Merhaba %s"},
+		"users.deleted":  {plural.One: "%d kullanıcı silindi.", plural.Other: "%d kullanıcı silindi."},
+		"users.only_tr":  {plural.Other: "Yalnızca Türkçe"},
+	}
+	if got := b.catalogs[language.Turkish]; !reflect.DeepEqual(got, want) {
+		t.Errorf("tr kataloğu = %v", got)
+	}
+	if b.Location() != time.UTC {
+		t.Errorf("Location = %v, UTC bekleniyordu", b.Location())
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		fallback string
+		wantErr  string
+	}{
+		{"geçersiz varsayılan dil", map[string]string{"tr.json": "{}"}, "türkçe", `varsayılan dil geçersiz: "türkçe"`},
+		{"varsayılan katalog yok", map[string]string{"en.json": "{}"}, "tr", `varsayılan dil "tr" için`},
+		{"dil kodu olmayan dosya", map[string]string{"tr.json": "{}", "messages.json": "{}"}, "tr", "messages.json: dosya adı bir dil kodu değil"},
+		{"bozuk JSON", map[string]string{"tr.json": "{"}, "tr", "tr.json:"},
+		{"metin olmayan mesaj", map[string]string{"tr.json": `{"users": {"count": 3}}`}, "tr", "users.count: mesaj metin veya nesne olmalı"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeCatalogs(t, tt.files), tt.fallback)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("hata = %v, %q bekleniyordu", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "yok"), "tr"); err == nil {
+		t.Error("olmayan dizin hata vermedi")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	b := testBundle(t, "tr")
+	tests := []struct {
+		values []string
+		want   language.Tag
+		ok     bool
+	}{
+		{[]string{"en"}, language.English, true},
+		{[]string{"en-US,en;q=0.9"}, language.English, true},
+		{[]string{"en-GB"}, language.English, true},
+		{[]string{"de-DE,de;q=0.9,en;q=0.5"}, language.English, true},
+		{[]string{"tr-TR,tr;q=0.9,en;q=0.8"}, language.Turkish, true},
+		{[]string{"", "en"}, language.English, true},
+		{[]string{"tr", "en"}, language.Turkish, true},
+		{[]string{"fr", "en"}, language.English, true},
+		{[]string{";;;", "en"}, language.English, true},
+		{[]string{"fr-FR,de;q=0.5"}, language.Turkish, false},
+		{[]string{"*"}, language.Turkish, false},
+		{nil, language.Turkish, false},
+	}
+	for _, tt := range tests {
+		got, ok := b.Match(tt.values...)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Match(%q) = %v, %v; want %v, %v", tt.values, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFallback(t *testing.T) {
+	// LOCALE_DEFAULT=en iken eşleşmeyen dil İngilizceye düşer.
+	b := testBundle(t, "en")
+	if got, ok := b.Match("fr"); got != language.English || ok {
+		t.Errorf("Match(fr) = %v, %v; en, false bekleniyordu", got, ok)
+	}
+	if got := b.Localizer(language.French).Lang(); got != "en" {
+		t.Errorf("fr Localizer dili = %q, en bekleniyordu", got)
+	}
+	if got := b.Localizer(language.Turkish).T("users.only_tr"); got != "Yalnızca Türkçe" {
+		t.Errorf("tr'de tanımlı anahtar = %q", got)
+	}
+	if got := b.Localizer(language.English).T("users.only_tr"); got != "users.only_tr" {
+		t.Errorf("varsayılan dilde olmayan anahtar = %q, anahtarın kendisi bekleniyordu", got)
+	}
+
+	b = testBundle(t, "tr")
+	en := b.Localizer(language.English)
+	if got := en.T("users.only_tr"); got != "Yalnızca Türkçe" {
+		t.Errorf("en'de olmayan anahtar = %q, varsayılan dilin çevirisi bekleniyordu", got)
+	}
+	if got := en.T("users.missing"); got != "users.missing" {
+		t.Errorf("hiçbir katalogda olmayan anahtar = %q", got)
+	}
+	if got := b.Localizer(language.MustParse("en-AU")).Lang(); got != "en" {
+		t.Errorf("en-AU Localizer dili = %q, en bekleniyordu", got)
+	}
+}
+
+func TestLocalizerTranslate(t *testing.T) {
+	b := testBundle(t, "tr")
+	tr, en := b.Localizer(language.Turkish), b.Localizer(language.English)
+
+	tests := []struct {
+		got, want string
+	}{
+		{tr.T("users.greeting", "Ayşe"), "Merhaba Ayşe"},
+		{en.T("users.greeting", "Ayşe"), "Hello Ayşe"},
+		{en.T("users.created"), "User created."},
+		{en.N("users.deleted", 1), "1 user deleted."},
+		{en.N("users.deleted", 3), "3 users deleted."},
+		{en.N("users.deleted", 0), "0 users deleted."},
+		{tr.N("users.deleted", 3), "3 kullanıcı silindi."},
+		{en.N("users.created", 2), "User created."},
+	}
+	for i, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%d: %q, want %q", i, tt.got, tt.want)
+		}
+	}
+
+	wantLanguages := []Language{
+		{Code: "tr", Name: "Türkçe"},
+		{Code: "en", Name: "English", Selected: true},
+	}
+	if got := en.Languages(); !reflect.DeepEqual(got, wantLanguages) {
+		t.Errorf("Languages = %+v", got)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	b := testBundle(t, "tr")
+	istanbul, _ := LoadLocation("Europe/Istanbul")
+	at := time.Date(2024, 3, 1, 22, 30, 0, 0, time.UTC)
+
+	en := b.Localizer(language.English).WithLocation(istanbul)
+	if got := en.FormatDate(at); got != "03/02/2024" {
+		t.Errorf("FormatDate = %q", got)
+	}
+	if got := en.FormatDateTime(at); got != "2024-03-02 01:30:00" {
+		t.Errorf("biçimi tanımsız FormatDateTime = %q", got)
+	}
+	if got := en.FormatDate(time.Time{}); got != "" {
+		t.Errorf("sıfır zaman = %q", got)
+	}
+}
@@ -0,0 +1,39 @@
+package i18n
+
+import (
+	"sort"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestLocalesKeyParity uygulamanın kataloglarının aynı anahtarları
+// tanımladığını doğrular; eksik bir anahtar arayüzde sessizce varsayılan
+// dile düşer.
+func TestLocalesKeyParity(t *testing.T) {
+	b, err := Load("../../locales", "tr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, en := b.catalogs[language.Turkish], b.catalogs[language.English]
+	if len(tr) == 0 || len(en) == 0 {
+		t.Fatalf("katalog boş: tr %d, en %d anahtar", len(tr), len(en))
+	}
+	for _, key := range missingKeys(tr, en) {
+		t.Errorf("en.json'da eksik anahtar: %s", key)
+	}
+	for _, key := range missingKeys(en, tr) {
+		t.Errorf("tr.json'da eksik anahtar: %s", key)
+	}
+}
+
+func missingKeys(from, in catalog) []string {
+	var missing []string
+	for key := range from {
+		if _, ok := in[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,169 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Tarih biçimleri katalogdan okunur; dil kendi biçimini tanımlamazsa
+// varsayılan dilinki kullanılır.
+const (
+	DateLayoutKey            = "format.date"
+	DateTimeLayoutKey        = "format.datetime"
+	DateTimeSecondsLayoutKey = "format.datetime_seconds"
+)
+
+// Localizer bir isteğin diline göre mesaj çevirir ve tarihleri isteğin saat
+// diliminde biçimlendirir. Handler'larda From(c), şablonlarda .Locale ile
+// erişilir.
+type Localizer struct {
+	bundle   *Bundle
+	tag      language.Tag
+	location *time.Location
+}
+
+// Tag Localizer'ın dilidir.
+func (l *Localizer) Tag() language.Tag {
+	return l.tag
+}
+
+// Lang dil kodunu döner (ör. "tr"); <html lang> ve Content-Language için.
+func (l *Localizer) Lang() string {
+	return l.tag.String()
+}
+
+// Location tarihlerin gösterildiği saat dilimidir.
+func (l *Localizer) Location() *time.Location {
+	if l.location == nil {
+		return time.UTC
+	}
+	return l.location
+}
+
+// WithLocation aynı dilde, tarihleri loc saat diliminde gösteren bir kopya
+// döner.
+func (l *Localizer) WithLocation(loc *time.Location) *Localizer {
+	copied := *l
+	copied.location = loc
+	return &copied
+}
+
+// In t'yi Localizer'ın saat dilimine çevirir; ör. dışa aktarılan tarihlerin
+// ekrandakiyle aynı saati göstermesi için.
+func (l *Localizer) In(t time.Time) time.Time {
+	return t.In(l.Location())
+}
+
+// T key'in çevirisini döner. args verilirse mesaj fmt biçimi olarak
+// kullanılır; çeviriler argüman sırasını değiştirmek için %[2]s gibi
+// sıralı yer tutucular kullanabilir. Anahtar hiçbir katalogda yoksa
+// eksik çevirinin fark edilmesi için anahtarın kendisi döner.
+func (l *Localizer) T(key string, args ...interface{}) string {
+	msg, ok := l.bundle.lookup(l.tag, key)
+	if !ok {
+		return key
+	}
+	return format(msg[plural.Other], args)
+}
+
+// N count'a göre key'in uygun çoğul biçimini seçer; count ilk argüman
+// olarak mesaja verilir, args ondan sonra gelir:
+//
+//	l.N("users.bulk.succeeded", 3) → "3 kullanıcı için işlem uygulandı."
+func (l *Localizer) N(key string, count int, args ...interface{}) string {
+	msg, ok := l.bundle.lookup(l.tag, key)
+	if !ok {
+		return key
+	}
+	text, ok := msg[l.pluralForm(count)]
+	if !ok {
+		text = msg[plural.Other]
+	}
+	return format(text, append([]interface{}{count}, args...))
+}
+
+func (l *Localizer) pluralForm(count int) plural.Form {
+	if count < 0 {
+		count = -count
+	}
+	return plural.Cardinal.MatchPlural(l.tag, count, 0, 0, 0, 0)
+}
+
+// Error hatayı kullanıcıya gösterilecek metne çevirir. Zincirde çeviri
+// anahtarı taşıyan bir hata (bkz. customerrors.Error) varsa onun çevirisi,
+// yoksa err.Error() döner.
+func (l *Localizer) Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	var keyed interface {
+		MessageKey() string
+		MessageArgs() []interface{}
+	}
+	if errors.As(err, &keyed) {
+		if _, ok := l.bundle.lookup(l.tag, keyed.MessageKey()); ok {
+			return l.T(keyed.MessageKey(), keyed.MessageArgs()...)
+		}
+	}
+	return err.Error()
+}
+
+// FormatDate t'yi isteğin saat diliminde, dilin tarih biçimiyle yazar;
+// sıfır zaman boş döner.
+func (l *Localizer) FormatDate(t time.Time) string {
+	return l.formatTime(t, DateLayoutKey)
+}
+
+// FormatDateTime t'yi dilin tarih-saat biçimiyle yazar.
+func (l *Localizer) FormatDateTime(t time.Time) string {
+	return l.formatTime(t, DateTimeLayoutKey)
+}
+
+// FormatDateTimeSeconds t'yi saniyeleriyle birlikte yazar.
+func (l *Localizer) FormatDateTimeSeconds(t time.Time) string {
+	return l.formatTime(t, DateTimeSecondsLayoutKey)
+}
+
+func (l *Localizer) formatTime(t time.Time, layoutKey string) string {
+	if t.IsZero() {
+		return ""
+	}
+	layout := l.T(layoutKey)
+	if layout == layoutKey {
+		layout = time.DateTime
+	}
+	return l.In(t).Format(layout)
+}
+
+// Language dil seçicide gösterilecek bir dildir.
+type Language struct {
+	Code     string
+	Name     string
+	Selected bool
+}
+
+// Languages yüklenmiş dilleri kendi adlarıyla ("Türkçe", "English") döner;
+// ad her dilin kataloğundaki language.name anahtarından okunur.
+func (l *Localizer) Languages() []Language {
+	languages := make([]Language, 0, len(l.bundle.tags))
+	for _, tag := range l.bundle.tags {
+		name := tag.String()
+		if msg, ok := l.bundle.catalogs[tag]["language.name"]; ok {
+			name = msg[plural.Other]
+		}
+		languages = append(languages, Language{Code: tag.String(), Name: name, Selected: tag == l.tag})
+	}
+	return languages
+}
+
+func format(text string, args []interface{}) string {
+	if len(args) == 0 || !strings.Contains(text, "%") {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
@@ -0,0 +1,148 @@
+package i18n
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/language"
+)
+
+const (
+	// CookieName dil seçicide seçilen dilin saklandığı cookie'dir; oturum
+	// kapatıldıktan sonra da giriş sayfasının aynı dilde açılmasını sağlar.
+	CookieName     = "lang"
+	cookieLifetime = 365 * 24 * time.Hour
+
+	localsKey = "i18n_localizer"
+)
+
+// bundle Init ile yüklenen katalogları tutar. Init çağrılmadan önce boş bir
+// Türkçe bundle kullanılır; bu durumda T anahtarların kendisini döner.
+var bundle atomic.Pointer[Bundle]
+
+func init() {
+	bundle.Store(newBundle(language.Turkish, map[language.Tag]catalog{language.Turkish: {}}))
+}
+
+// Init dir içindeki katalogları yükler ve uygulama genelinde kullanılacak
+// bundle olarak ayarlar. timezone, saat dilimi seçmemiş kullanıcılar için
+// varsayılan IANA saat dilimidir.
+func Init(dir, fallback, timezone string) error {
+	loc, ok := LoadLocation(timezone)
+	if !ok {
+		return fmt.Errorf("varsayılan saat dilimi geçersiz: %q", timezone)
+	}
+	b, err := Load(dir, fallback)
+	if err != nil {
+		return err
+	}
+	b.SetLocation(loc)
+	bundle.Store(b)
+	return nil
+}
+
+// Default Init ile yüklenen bundle'ı döner.
+func Default() *Bundle {
+	return bundle.Load()
+}
+
+// Middleware isteğin dilini şu sırayla belirler: kullanıcının dil seçicide
+// seçtiği dil (lang cookie'si), tarayıcının Accept-Language başlığı,
+// varsayılan dil. Oturum açmış kullanıcının kayıtlı tercihleri ApplyUser ile
+// uygulanır.
+func Middleware(c *fiber.Ctx) error {
+	b := Default()
+	tag, _ := b.Match(c.Cookies(CookieName), c.Get(fiber.HeaderAcceptLanguage))
+	setLocalizer(c, b.Localizer(tag))
+	c.Vary(fiber.HeaderAcceptLanguage)
+	return c.Next()
+}
+
+// From isteğin Localizer'ını döner; Middleware çalışmamışsa varsayılan dil
+// kullanılır.
+func From(c *fiber.Ctx) *Localizer {
+	if l, ok := c.Locals(localsKey).(*Localizer); ok {
+		return l
+	}
+	b := Default()
+	return b.Localizer(b.Fallback())
+}
+
+// SetPreferred isteğin dilini locale ile değiştirir; locale desteklenmiyorsa
+// mevcut dil korunur ve false döner. İsteğin saat dilimi değişmez.
+func SetPreferred(c *fiber.Ctx, locale string) bool {
+	b := Default()
+	tag, ok := b.Match(locale)
+	if !ok {
+		return false
+	}
+	setLocalizer(c, b.Localizer(tag).WithLocation(From(c).Location()))
+	return true
+}
+
+// SetTimezone isteğin tarihlerini name saat diliminde gösterir; name geçerli
+// bir saat dilimi değilse mevcut saat dilimi korunur ve false döner.
+func SetTimezone(c *fiber.Ctx, name string) bool {
+	loc, ok := LoadLocation(name)
+	if !ok {
+		return false
+	}
+	setLocalizer(c, From(c).WithLocation(loc))
+	return true
+}
+
+// ApplyUser oturum açmış kullanıcının kayıtlı dil ve saat dilimi
+// tercihlerini isteğe uygular; boş tercih varsayılanı korur. Dil seçicide
+// seçilmiş bir dil varsa kayıtlı dil onu ezmez, yalnızca tarayıcının diline
+// göre önceliklidir. Kayıtlı dili olan kullanıcının dil cookie'si girişte
+// silindiğinden cookie yalnızca oturum sırasında dil seçiciyle yapılan
+// değişiklikleri taşır.
+func ApplyUser(c *fiber.Ctx, locale, timezone string) {
+	if locale != "" && c.Cookies(CookieName) == "" {
+		SetPreferred(c, locale)
+	}
+	if timezone != "" {
+		SetTimezone(c, timezone)
+	}
+}
+
+// SetCookie locale desteklenen bir dilse onu dil cookie'sine yazar ve
+// isteğin dilini de hemen değiştirir.
+func SetCookie(c *fiber.Ctx, locale string, secure bool) bool {
+	tag, ok := Default().Match(locale)
+	if !ok {
+		return false
+	}
+	c.Cookie(&fiber.Cookie{
+		Name:     CookieName,
+		Value:    tag.String(),
+		Path:     "/",
+		Expires:  time.Now().Add(cookieLifetime),
+		Secure:   secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return SetPreferred(c, tag.String())
+}
+
+// ClearCookie dil cookie'sini siler; sonraki isteklerde dil kullanıcının
+// kayıtlı tercihinden veya tarayıcıdan belirlenir.
+func ClearCookie(c *fiber.Ctx, secure bool) {
+	c.Cookie(&fiber.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		Secure:   secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func setLocalizer(c *fiber.Ctx, l *Localizer) {
+	c.Locals(localsKey, l)
+	c.Set(fiber.HeaderContentLanguage, l.Lang())
+}
@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func useBundle(t *testing.T, b *Bundle) {
+	t.Helper()
+	previous := Default()
+	bundle.Store(b)
+	t.Cleanup(func() { bundle.Store(previous) })
+}
+
+func TestMiddleware(t *testing.T) {
+	useBundle(t, testBundle(t, "tr"))
+
+	app := fiber.New()
+	app.Use(Middleware)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(From(c).T("users.created"))
+	})
+
+	tests := []struct {
+		name, cookie, acceptLanguage string
+		want, wantLang               string
+	}{
+		{"başlık yok", "", "", "Kullanıcı oluşturuldu.", "tr"},
+		{"Accept-Language", "", "en-US,en;q=0.9", "User created.", "en"},
+		{"desteklenmeyen dil", "", "fr-FR,de;q=0.8", "Kullanıcı oluşturuldu.", "tr"},
+		{"cookie başlığı ezer", "tr", "en-US,en;q=0.9", "Kullanıcı oluşturuldu.", "tr"},
+		{"cookie ile İngilizce", "en", "tr-TR", "User created.", "en"},
+		{"geçersiz cookie", "klingon", "en", "User created.", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				req.Header.Set(fiber.HeaderCookie, CookieName+"="+tt.cookie)
+			}
+			if tt.acceptLanguage != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.want {
+				t.Errorf("gövde = %q, want %q", body, tt.want)
+			}
+			if got := resp.Header.Get(fiber.HeaderContentLanguage); got != tt.wantLang {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLang)
+			}
+			if got := resp.Header.Get(fiber.HeaderVary); got != fiber.HeaderAcceptLanguage {
+				t.Errorf("Vary = %q", got)
+			}
+		})
+	}
+}
+
+func TestApplyUserAndSetCookie(t *testing.T) {
+	useBundle(t, testBundle(t, "tr"))
+
+	app := fiber.New()
+	app.Use(Middleware)
+	app.Get("/user", func(c *fiber.Ctx) error {
+		ApplyUser(c, "en", "Europe/Istanbul")
+		return c.SendString(From(c).Lang() + " " + From(c).Location().String())
+	})
+	app.Get("/set", func(c *fiber.Ctx) error {
+		if !SetCookie(c, c.Query("lang"), false) {
+			return c.SendStatus(fiber.StatusBadRequest)
+		}
+		return c.SendString(From(c).Lang())
+	})
+
+	get := func(path, cookie string) (string, *http.Response) {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodGet, path, nil)
+		if cookie != "" {
+			req.Header.Set(fiber.HeaderCookie, CookieName+"="+cookie)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body), resp
+	}
+
+	if body, _ := get("/user", ""); body != "en Europe/Istanbul" {
+		t.Errorf("kayıtlı tercih = %q", body)
+	}
+	if body, _ := get("/user", "tr"); body != "tr Europe/Istanbul" {
+		t.Errorf("cookie varken kayıtlı tercih = %q, cookie dili bekleniyordu", body)
+	}
+
+	body, resp := get("/set?lang=en-GB", "")
+	if body != "en" {
+		t.Errorf("SetCookie sonrası dil = %q", body)
+	}
+	if got := resp.Header.Get(fiber.HeaderSetCookie); !strings.HasPrefix(got, CookieName+"=en;") {
+		t.Errorf("Set-Cookie = %q", got)
+	}
+	if _, resp := get("/set?lang=fr", ""); resp.StatusCode != fiber.StatusBadRequest || resp.Header.Get(fiber.HeaderSetCookie) != "" {
+		t.Errorf("desteklenmeyen dil cookie'ye yazıldı: %d %q", resp.StatusCode, resp.Header.Get(fiber.HeaderSetCookie))
+	}
+}
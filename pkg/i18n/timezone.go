@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"sync"
+	"time"
+
+	// Saat dilimi veritabanı binary'ye gömülür; zoneinfo içermeyen
+	// container imajlarında da LoadLocation çalışır.
+	_ "time/tzdata"
+)
+
+// Timezones profil sayfasında seçilebilen saat dilimleridir. Liste yalnızca
+// seçiciyi besler; LoadLocation'ın tanıdığı her IANA adı geçerlidir.
+var Timezones = []string{
+	"Europe/Istanbul",
+	"UTC",
+	"Europe/London",
+	"Europe/Berlin",
+	"Europe/Paris",
+	"Europe/Moscow",
+	"Asia/Baku",
+	"Asia/Dubai",
+	"Asia/Tokyo",
+	"America/New_York",
+	"America/Chicago",
+	"America/Los_Angeles",
+}
+
+// locations LoadLocation sonuçlarını önbellekler; time.LoadLocation her
+// çağrıda tzdata'yı yeniden okur ve tercih her istekte uygulanır.
+var locations sync.Map
+
+// LoadLocation name saat dilimini döner. Boş ad ve sunucunun ayarına bağlı
+// olan "Local" geçersiz sayılır.
+func LoadLocation(name string) (*time.Location, bool) {
+	if name == "" || name == "Local" {
+		return nil, false
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), true
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	locations.Store(name, loc)
+	return loc, true
+}
@@ -0,0 +1,125 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+)
+
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+type Manager struct {
+	mu              sync.Mutex
+	hooks           []Hook
+	started         []Hook
+	failures        chan error
+	shutdownTimeout time.Duration
+}
+
+func New(shutdownTimeout time.Duration) *Manager {
+	return &Manager{
+		failures:        make(chan error, 1),
+		shutdownTimeout: shutdownTimeout,
+	}
+}
+
+func (m *Manager) Append(hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook)
+}
+
+// Fail, arka planda çalışan bir bileşenin (ör. HTTP dinleyicisi) kurtarılamaz
+// hatasını bildirir; Run bu hatayı alınca düzenli kapatmayı başlatır.
+func (m *Manager) Fail(name string, err error) {
+	select {
+	case m.failures <- fmt.Errorf("%s: %w", name, err):
+	default:
+	}
+}
+
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := append([]Hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook.OnStart != nil {
+			logs.Log.Debug("Bileşen başlatılıyor", zap.String("component", hook.Name))
+			if err := hook.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("%s başlatılamadı: %w", hook.Name, err)
+				stopCtx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+				defer cancel()
+				return errors.Join(startErr, m.Stop(stopCtx))
+			}
+		}
+
+		m.mu.Lock()
+		m.started = append(m.started, hook)
+		m.mu.Unlock()
+	}
+	return nil
+}
+
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		hook := started[i]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		logs.Log.Debug("Bileşen durduruluyor", zap.String("component", hook.Name))
+		if err := hook.OnStop(ctx); err != nil {
+			logs.Log.Error("Bileşen durdurulurken hata oluştu", zap.String("component", hook.Name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s durdurulamadı: %w", hook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run tüm bileşenleri başlatır, kapatma sinyali ya da bir bileşen hatası gelene
+// kadar bekler ve ardından bileşenleri ters sırada, shutdownTimeout süresi
+// içinde durdurur. Dönen hata tüm başlatma/çalışma/durdurma hatalarını içerir.
+func (m *Manager) Run(ctx context.Context) error {
+	if err := m.Start(ctx); err != nil {
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	var runErr error
+	select {
+	case sig := <-signals:
+		logs.Log.Info("Kapatma sinyali alındı, uygulama kapatılıyor...", zap.String("signal", sig.String()))
+	case runErr = <-m.failures:
+		logs.Log.Error("Bir bileşen çalışırken hata verdi, uygulama kapatılıyor...", zap.Error(runErr))
+	case <-ctx.Done():
+		logs.Log.Info("Uygulama bağlamı sonlandı, uygulama kapatılıyor...")
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	return errors.Join(runErr, m.Stop(stopCtx))
+}
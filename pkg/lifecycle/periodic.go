@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+)
+
+// Periodic fn'i interval aralıklarla arka planda çalıştıran bir Hook döner.
+// İlk çalıştırma başlatmadan hemen sonra yapılır. Durdurulurken fn'e verilen
+// context iptal edilir ve devam eden turun bitmesi beklenir.
+func Periodic(name string, interval time.Duration, fn func(ctx context.Context)) Hook {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					fn(runCtx)
+					select {
+					case <-ticker.C:
+					case <-runCtx.Done():
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if cancel == nil {
+				return nil
+			}
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
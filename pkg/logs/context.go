@@ -0,0 +1,23 @@
+package logs
+
+import "context"
+
+const RequestIDContextKey = "request_id"
+
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+const ClientIPContextKey = "client_ip"
+
+func ClientIPFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	ip, _ := ctx.Value(ClientIPContextKey).(string)
+	return ip
+}
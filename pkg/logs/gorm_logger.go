@@ -0,0 +1,125 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+type GormLogger struct {
+	logger                    *zap.Logger
+	level                     gormlogger.LogLevel
+	slowThreshold             time.Duration
+	redactParams              bool
+	ignoreRecordNotFoundError bool
+}
+
+type GormLoggerConfig struct {
+	LogLevel                  gormlogger.LogLevel
+	SlowThreshold             time.Duration
+	RedactParams              bool
+	IgnoreRecordNotFoundError bool
+}
+
+func NewGormLogger(config GormLoggerConfig) *GormLogger {
+	base := Log
+	if base == nil {
+		base = zap.NewNop()
+	}
+	return &GormLogger{
+		logger:                    base.Named("gorm").WithOptions(zap.WithCaller(false)),
+		level:                     config.LogLevel,
+		slowThreshold:             config.SlowThreshold,
+		redactParams:              config.RedactParams,
+		ignoreRecordNotFoundError: config.IgnoreRecordNotFoundError,
+	}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	newLogger := *l
+	newLogger.level = level
+	return &newLogger
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.logger.Info(fmt.Sprintf(msg, data...), l.contextFields(ctx)...)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.logger.Warn(fmt.Sprintf(msg, data...), l.contextFields(ctx)...)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.logger.Error(fmt.Sprintf(msg, data...), l.contextFields(ctx)...)
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error &&
+		(!errors.Is(err, gorm.ErrRecordNotFound) || !l.ignoreRecordNotFoundError):
+		l.logger.Error("SQL sorgusu hata verdi", l.traceFields(ctx, elapsed, fc, zap.Error(err))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.logger.Warn("Yavaş SQL sorgusu", l.traceFields(ctx, elapsed, fc,
+			zap.Bool("slow_query", true),
+			zap.Duration("slow_threshold", l.slowThreshold),
+		)...)
+	case l.level >= gormlogger.Info:
+		// DB_LOG_LEVEL=info her sorguyu ister; uygulama logu info seviyesindeyken
+		// de görünmesi için Debug yerine Info ile yazılır.
+		l.logger.Info("SQL sorgusu çalıştırıldı", l.traceFields(ctx, elapsed, fc)...)
+	}
+}
+
+// ParamsFilter, gorm.ParamsFilter arayüzünü uygular. Parametreler gizlendiğinde
+// GORM SQL'i değerler yerine yer tutucularla açıklar.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.redactParams {
+		return sql, nil
+	}
+	return sql, params
+}
+
+func (l *GormLogger) traceFields(ctx context.Context, elapsed time.Duration, fc func() (string, int64), extra ...zap.Field) []zap.Field {
+	sql, rows := fc()
+
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Float64("duration_ms", float64(elapsed.Nanoseconds())/1e6),
+		zap.String("source", utils.FileWithLineNum()),
+	}
+	if rows >= 0 {
+		fields = append(fields, zap.Int64("rows_affected", rows))
+	}
+	fields = append(fields, extra...)
+	return append(fields, l.contextFields(ctx)...)
+}
+
+func (l *GormLogger) contextFields(ctx context.Context) []zap.Field {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return []zap.Field{zap.String("request_id", requestID)}
+	}
+	return nil
+}
+
+var (
+	_ gormlogger.Interface = (*GormLogger)(nil)
+	_ gorm.ParamsFilter    = (*GormLogger)(nil)
+)
@@ -1,8 +1,6 @@
 package logs
 
 import (
-	"os"
-
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -10,12 +8,11 @@ import (
 var Log *zap.Logger
 var SLog *zap.SugaredLogger
 
-func InitLogger() {
+func InitLogger(env, logLevel string) {
 	if Log != nil {
 		return
 	}
 
-	env := os.Getenv("APP_ENV")
 	var config zap.Config
 	var err error
 	var level zapcore.Level
@@ -32,11 +29,10 @@ func InitLogger() {
 		level = zapcore.DebugLevel
 	}
 
-	logLevelEnv := os.Getenv("LOG_LEVEL")
-	if logLevelEnv != "" {
-		err = level.Set(logLevelEnv)
+	if logLevel != "" {
+		err = level.Set(logLevel)
 		if err != nil {
-			panic("Geçersiz LOG_LEVEL '" + logLevelEnv + "': " + err.Error())
+			panic("Geçersiz LOG_LEVEL '" + logLevel + "': " + err.Error())
 		}
 	}
 	config.Level = zap.NewAtomicLevelAt(level)
@@ -0,0 +1,187 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "zatrano"
+
+const (
+	ResultSuccess            = "success"
+	ResultFailure            = "failure"
+	ResultInvalidCredentials = "invalid_credentials"
+	ResultInactive           = "inactive"
+	ResultError              = "error"
+)
+
+const (
+	UserOperationCreate  = "create"
+	UserOperationUpdate  = "update"
+	UserOperationDelete  = "delete"
+	UserOperationRestore = "restore"
+	UserOperationPurge   = "purge"
+)
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "İşlenen HTTP isteklerinin rota, metot ve durum koduna göre sayısı.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP isteklerinin rota, metot ve durum koduna göre işlenme süresi.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Şu anda işlenmekte olan HTTP isteği sayısı.",
+	})
+
+	authLoginAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "login_attempts_total",
+		Help:      "Giriş denemelerinin sonuca göre sayısı.",
+	}, []string{"result"})
+
+	sessionsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sessions",
+		Name:      "created_total",
+		Help:      "Başarılı giriş sonrası oluşturulan oturum sayısı.",
+	})
+
+	sessionsDestroyed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "sessions",
+		Name:      "destroyed_total",
+		Help:      "Çıkış veya geçersiz oturum nedeniyle sonlandırılan oturum sayısı.",
+	})
+
+	sessionsActive = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "sessions",
+		Name:      "active",
+		Help:      "Oturum deposundaki süresi dolmamış oturum sayısı.",
+	}, func() float64 {
+		if count, ok := activeSessions.Load().(func() int); ok {
+			return float64(count())
+		}
+		return 0
+	})
+
+	userOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "users",
+		Name:      "operations_total",
+		Help:      "Kullanıcı oluşturma/güncelleme/silme işlemlerinin sonuca göre sayısı.",
+	}, []string{"operation", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		httpRequestsInFlight,
+		authLoginAttempts,
+		sessionsCreated,
+		sessionsDestroyed,
+		sessionsActive,
+		userOperations,
+	)
+}
+
+func RegisterDBStats(sqlDB *sql.DB, dbName string) error {
+	err := Registry.Register(collectors.NewDBStatsCollector(sqlDB, dbName))
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		return nil
+	}
+	return err
+}
+
+func HTTPMiddleware(c *fiber.Ctx) error {
+	start := time.Now()
+	httpRequestsInFlight.Inc()
+	defer httpRequestsInFlight.Dec()
+
+	err := c.Next()
+
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = fiber.StatusInternalServerError
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			status = fiberErr.Code
+		}
+	}
+
+	route := c.Route().Path
+	if route == "" {
+		route = "unmatched"
+	}
+	labels := prometheus.Labels{
+		"method": c.Method(),
+		"route":  route,
+		"status": strconv.Itoa(status),
+	}
+	httpRequestsTotal.With(labels).Inc()
+	httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+func RecordLoginAttempt(result string) {
+	authLoginAttempts.WithLabelValues(result).Inc()
+}
+
+func RecordSessionCreated() {
+	sessionsCreated.Inc()
+}
+
+func RecordSessionDestroyed() {
+	sessionsDestroyed.Inc()
+}
+
+// activeSessions aktif oturum gauge'inin her toplamada çağırdığı sayaçtır.
+var activeSessions atomic.Value
+
+// SetActiveSessionsSource aktif oturum sayısının okunacağı fonksiyonu
+// belirler; yeni bir oturum deposu oluşturulduğunda öncekinin yerini alır.
+func SetActiveSessionsSource(count func() int) {
+	activeSessions.Store(count)
+}
+
+func RecordUserOperation(operation string, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	}
+	userOperations.WithLabelValues(operation, result).Inc()
+}
@@ -0,0 +1,36 @@
+package queryparams
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("geçersiz sayfalama imleci")
+
+// Cursor keyset sayfalamada bir sayfanın sınır satırını tanımlar. Values,
+// sıralama kolonlarının (en sonda id) o satırdaki değerleridir. Sort imlecin
+// hangi sıralama için üretildiğini tutar; sıralama değişirse imleç geçersizdir.
+type Cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// Encode imleci query string'de taşınabilecek opak bir metne çevirir.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(encoded, sort string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || len(cursor.Values) == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
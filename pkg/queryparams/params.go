@@ -1,22 +1,80 @@
 package queryparams
 
-import "math"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/pkg/constants"
+)
+
+var filterKeyPattern = regexp.MustCompile(`^filter\[([A-Za-z0-9_]+)\](?:\[(from|to)\])?$`)
 
 type ListParams struct {
-	Name string `query:"name"`
+	Search  string
+	Sort    []SortField
+	Filters map[string]Filter
+
+	Page    int
+	PerPage int
+
+	// UseCursor query string'de cursor parametresi varsa (boş olsa bile)
+	// true olur ve OFFSET yerine keyset sayfalama kullanılır.
+	UseCursor bool
+	Cursor    string
+
+	// CountMode boşsa modelin ListSpec'indeki değer kullanılır. Kullanıcıdan
+	// alınmaz; servisler gerektiğinde ayarlar.
+	CountMode CountMode
 
-	SortBy  string `query:"sortBy"`
-	OrderBy string `query:"orderBy"`
+	// SortExplicit sıralama query string'den geldiyse true, spec'teki
+	// varsayılan kullanıldıysa false olur. Varsayılan sıralamada arama
+	// sonuçları benzerliğe göre sıralanabilir (bkz. ListSpec.SearchRanked).
+	SortExplicit bool
+
+	// Location tarih filtrelerindeki günlerin hangi saat diliminde
+	// başlayıp bittiğidir; nil ise UTC kullanılır. Kullanıcıdan alınmaz;
+	// handler'lar isteğin saat dilimini ayarlar.
+	Location *time.Location
+}
+
+// DateLocation tarih filtrelerinin yorumlanacağı saat dilimini döner.
+func (p ListParams) DateLocation() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+	return p.Location
+}
 
-	Page    int `query:"page"`
-	PerPage int `query:"perPage"`
+// RankBySearch arama sonuçlarının benzerliğe göre sıralanıp sıralanmayacağını
+// döner: spec izin vermeli, arama yapılmış olmalı ve kullanıcı bir sıralama
+// seçmemiş olmalıdır. İmleçli sayfalamada sıra bir kolona dayanmadığı için
+// kullanılmaz.
+func (p ListParams) RankBySearch(spec ListSpec) bool {
+	return spec.SearchRanked && p.Search != "" && !p.SortExplicit && !p.UseCursor
 }
 
+// PaginationMeta sayfa bilgisini taşır. Keyset sayfalamada CurrentPage 0'dır.
+// Toplam bilinmiyorsa (CountNone) TotalItems -1 ve TotalPages 0 olur.
 type PaginationMeta struct {
-	CurrentPage int   `json:"current_page"`
-	PerPage     int   `json:"per_page"`
-	TotalItems  int64 `json:"total_items"`
-	TotalPages  int   `json:"total_pages"`
+	CurrentPage int       `json:"current_page"`
+	PerPage     int       `json:"per_page"`
+	TotalItems  int64     `json:"total_items"`
+	TotalPages  int       `json:"total_pages"`
+	CountMode   CountMode `json:"count_mode"`
+
+	HasNext    bool   `json:"has_next"`
+	HasPrev    bool   `json:"has_prev"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
 }
 
 type PaginatedResult struct {
@@ -24,6 +82,280 @@ type PaginatedResult struct {
 	Meta PaginationMeta `json:"meta"`
 }
 
+// Parse c.Queries() çıktısını spec'e göre ListParams'a çevirir. Desteklenen
+// biçim: q=metin, sort=-created_at,name, filter[status]=true,
+// filter[type]=dashboard,panel, filter[created_at][from]=2024-01-01,
+// page=2, perPage=50, cursor=<imleç>. Spec'te olmayan alanlar yok sayılır; geçersiz değerler
+// atlanır ve birlikte hata olarak döner, geçerli kısım yine de kullanılabilir.
+func Parse(queries map[string]string, spec ListSpec) (ListParams, error) {
+	params := ListParams{
+		Search:  strings.TrimSpace(queries["q"]),
+		Filters: make(map[string]Filter),
+		Page:    constants.DefaultPage,
+		PerPage: constants.DefaultPerPage,
+	}
+	var errs []error
+
+	if v := queries["page"]; v != "" {
+		if page, err := strconv.Atoi(v); err == nil && page > 0 {
+			params.Page = page
+		} else {
+			errs = append(errs, fmt.Errorf("geçersiz sayfa: %q", v))
+		}
+	}
+	if v := queries["perPage"]; v != "" {
+		if perPage, err := strconv.Atoi(v); err == nil && perPage > 0 && perPage <= constants.MaxPerPage {
+			params.PerPage = perPage
+		} else {
+			errs = append(errs, fmt.Errorf("geçersiz sayfa başına kayıt: %q", v))
+		}
+	}
+
+	if cursor, ok := queries["cursor"]; ok {
+		params.UseCursor = true
+		params.Cursor = cursor
+	}
+
+	params.Sort = parseSort(queries["sort"], spec)
+	params.SortExplicit = len(params.Sort) > 0
+	if !params.SortExplicit {
+		params.Sort = parseSort(spec.DefaultSort, spec)
+	}
+
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		match := filterKeyPattern.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		field, part := match[1], match[2]
+		value := strings.TrimSpace(queries[key])
+		filterSpec, ok := spec.Filters[field]
+		if !ok || value == "" {
+			continue
+		}
+
+		filter, exists := params.Filters[field]
+		if !exists {
+			filter = Filter{Field: field, Type: filterSpec.Type}
+		}
+		if err := filter.set(part, value, filterSpec.Values); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		params.Filters[field] = filter
+	}
+
+	return params, errors.Join(errs...)
+}
+
+func parseSort(value string, spec ListSpec) []SortField {
+	var fields []SortField
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		desc := strings.HasPrefix(item, "-")
+		field := strings.TrimPrefix(item, "-")
+		if _, ok := spec.Sorts[field]; !ok || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, SortField{Field: field, Desc: desc})
+	}
+	return fields
+}
+
+func (f *Filter) set(part, value string, allowed []string) error {
+	switch f.Type {
+	case FilterRange, FilterDateRange:
+		if part == "" {
+			return fmt.Errorf("filter[%s] için [from] veya [to] belirtilmeli", f.Field)
+		}
+		if err := validateRangeValue(f.Type, value); err != nil {
+			return fmt.Errorf("filter[%s][%s] geçersiz: %q", f.Field, part, value)
+		}
+		if part == "from" {
+			f.From = value
+		} else {
+			f.To = value
+		}
+	case FilterIn:
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			if !isAllowed(item, allowed) {
+				return invalidValueError(f.Field, item, allowed)
+			}
+			values = append(values, item)
+		}
+		f.Values = values
+	case FilterBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("filter[%s] true/false olmalı: %q", f.Field, value)
+		}
+		f.Values = []string{value}
+	default:
+		if part != "" {
+			return fmt.Errorf("filter[%s] aralık desteklemiyor", f.Field)
+		}
+		if f.Type == FilterEq && !isAllowed(value, allowed) {
+			return invalidValueError(f.Field, value, allowed)
+		}
+		f.Values = []string{value}
+	}
+	return nil
+}
+
+func isAllowed(value string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, v := range allowed {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidValueError(field, value string, allowed []string) error {
+	return fmt.Errorf("filter[%s] %s değerlerinden biri olmalı: %q", field, strings.Join(allowed, ", "), value)
+}
+
+func validateRangeValue(filterType FilterType, value string) error {
+	if filterType == FilterDateRange {
+		_, err := time.Parse(DateLayout, value)
+		return err
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	return err
+}
+
+// Query parametreleri tekrar query string'e çevirir; sayfa linkleri ve
+// sıralama başlıkları mevcut filtreleri kaybetmemek için bunu kullanır.
+// Varsayılan sıralama yazılmaz; Parse onu zaten yeniden uygular.
+func (p ListParams) Query() url.Values {
+	values := url.Values{}
+	if p.Search != "" {
+		values.Set("q", p.Search)
+	}
+	if sortValue := p.SortString(); sortValue != "" && p.SortExplicit {
+		values.Set("sort", sortValue)
+	}
+	for field, filter := range p.Filters {
+		key := "filter[" + field + "]"
+		switch filter.Type {
+		case FilterRange, FilterDateRange:
+			if filter.From != "" {
+				values.Set(key+"[from]", filter.From)
+			}
+			if filter.To != "" {
+				values.Set(key+"[to]", filter.To)
+			}
+		default:
+			if len(filter.Values) > 0 {
+				values.Set(key, strings.Join(filter.Values, ","))
+			}
+		}
+	}
+	if p.PerPage != constants.DefaultPerPage {
+		values.Set("perPage", strconv.Itoa(p.PerPage))
+	}
+	if p.UseCursor {
+		values.Set("cursor", p.Cursor)
+	} else if p.Page > 1 {
+		values.Set("page", strconv.Itoa(p.Page))
+	}
+	return values
+}
+
+// FilterQuery arama, filtre ve sıralama parametrelerini sayfalama olmadan
+// döner; dışa aktarma formları mevcut listeyi bununla tarif eder.
+func (p ListParams) FilterQuery() url.Values {
+	values := p.Query()
+	values.Del("page")
+	values.Del("perPage")
+	values.Del("cursor")
+	return values
+}
+
+// URL mevcut parametrelerde key'i value ile değiştirip "?..." biçiminde döner.
+// Sayfa veya imleç dışındaki bir parametre değişince ilk sayfaya dönülür.
+func (p ListParams) URL(key string, value interface{}) string {
+	values := p.Query()
+	if key != "page" && key != "cursor" {
+		values.Del("page")
+		if p.UseCursor {
+			values.Set("cursor", "")
+		}
+	}
+	if s := fmt.Sprint(value); s != "" || key == "cursor" {
+		values.Set(key, s)
+	} else {
+		values.Del(key)
+	}
+	return "?" + values.Encode()
+}
+
+// SortURL field için sıralama yönünü değiştiren linki döner (tek kolon).
+func (p ListParams) SortURL(field string) string {
+	next := field
+	if p.SortDirection(field) == "asc" {
+		next = "-" + field
+	}
+	return p.URL("sort", next)
+}
+
+// SortDirection field birincil sıralama kolonuysa "asc"/"desc", değilse "" döner.
+func (p ListParams) SortDirection(field string) string {
+	if len(p.Sort) == 0 || p.Sort[0].Field != field {
+		return ""
+	}
+	if p.Sort[0].Desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+func (p ListParams) SortString() string {
+	parts := make([]string, 0, len(p.Sort))
+	for _, s := range p.Sort {
+		if s.Desc {
+			parts = append(parts, "-"+s.Field)
+		} else {
+			parts = append(parts, s.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// FilterValue form alanlarını doldurmak için filtre değerini döner; part
+// "from" veya "to" verilirse aralığın ilgili ucu döner.
+func (p ListParams) FilterValue(field string, part ...string) string {
+	filter, ok := p.Filters[field]
+	if !ok {
+		return ""
+	}
+	if len(part) > 0 {
+		if part[0] == "from" {
+			return filter.From
+		}
+		return filter.To
+	}
+	return strings.Join(filter.Values, ",")
+}
+
+func (p ListParams) HasFilters() bool {
+	return p.Search != "" || len(p.Filters) > 0
+}
+
 func (p *ListParams) CalculateOffset() int {
 	if p.Page <= 0 {
 		p.Page = 1
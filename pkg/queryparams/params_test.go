@@ -0,0 +1,321 @@
+package queryparams
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testSpec() ListSpec {
+	return ListSpec{
+		Filters: map[string]FilterSpec{
+			"name":       {Column: "name", Type: FilterLike},
+			"status":     {Column: "status", Type: FilterBool},
+			"type":       {Column: "type", Type: FilterIn, Values: []string{"dashboard", "panel"}},
+			"table":      {Column: "table_name", Type: FilterEq, Values: []string{"users"}},
+			"record_id":  {Column: "record_id", Type: FilterEq},
+			"id":         {Column: "id", Type: FilterRange},
+			"created_at": {Column: "created_at", Type: FilterDateRange},
+		},
+		Sorts: map[string]string{
+			"id":      "id",
+			"name":    "name",
+			"account": "account",
+		},
+		DefaultSort: "-id",
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name          string
+		queries       map[string]string
+		page, perPage int
+		wantErr       string
+	}{
+		{"varsayılan", map[string]string{}, 1, 20, ""},
+		{"geçerli", map[string]string{"page": "3", "perPage": "50"}, 3, 50, ""},
+		{"sayı olmayan sayfa", map[string]string{"page": "abc"}, 1, 20, `geçersiz sayfa: "abc"`},
+		{"sıfır sayfa", map[string]string{"page": "0"}, 1, 20, `geçersiz sayfa: "0"`},
+		{"negatif perPage", map[string]string{"perPage": "-5"}, 1, 20, `geçersiz sayfa başına kayıt: "-5"`},
+		{"üst sınırı aşan perPage", map[string]string{"perPage": "101"}, 1, 20, `geçersiz sayfa başına kayıt: "101"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := Parse(tt.queries, testSpec())
+			if params.Page != tt.page || params.PerPage != tt.perPage {
+				t.Errorf("Page/PerPage = %d/%d, want %d/%d", params.Page, params.PerPage, tt.page, tt.perPage)
+			}
+			checkErr(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []SortField
+	}{
+		{"name", []SortField{{Field: "name"}}},
+		{"-name, account", []SortField{{Field: "name", Desc: true}, {Field: "account"}}},
+		{"name,-name,name", []SortField{{Field: "name"}}},
+		{"password,-name", []SortField{{Field: "name", Desc: true}}},
+		{"--name", nil},
+		{"", nil},
+		{",,", nil},
+	}
+	for _, tt := range tests {
+		if got := parseSort(tt.value, testSpec()); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSort(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseDefaultSort(t *testing.T) {
+	params, err := Parse(map[string]string{"sort": "password"}, testSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SortField{{Field: "id", Desc: true}}
+	if !reflect.DeepEqual(params.Sort, want) || params.SortExplicit {
+		t.Errorf("Sort = %+v (explicit %v), varsayılan %+v bekleniyordu", params.Sort, params.SortExplicit, want)
+	}
+}
+
+func TestFilterSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      Filter
+		part, value string
+		allowed     []string
+		want        Filter
+		wantErr     string
+	}{
+		{
+			name:   "in listesi",
+			filter: Filter{Field: "type", Type: FilterIn},
+			value:  " dashboard, ,panel ", allowed: []string{"dashboard", "panel"},
+			want: Filter{Field: "type", Type: FilterIn, Values: []string{"dashboard", "panel"}},
+		},
+		{
+			name:   "in izin verilmeyen değer",
+			filter: Filter{Field: "type", Type: FilterIn},
+			value:  "dashboard,admin", allowed: []string{"dashboard", "panel"},
+			want:    Filter{Field: "type", Type: FilterIn},
+			wantErr: `filter[type] dashboard, panel değerlerinden biri olmalı: "admin"`,
+		},
+		{
+			name:   "in kısıtsız",
+			filter: Filter{Field: "action", Type: FilterIn},
+			value:  "anything",
+			want:   Filter{Field: "action", Type: FilterIn, Values: []string{"anything"}},
+		},
+		{
+			name:   "eq izin verilmeyen değer",
+			filter: Filter{Field: "table", Type: FilterEq},
+			value:  "sessions", allowed: []string{"users"},
+			want:    Filter{Field: "table", Type: FilterEq},
+			wantErr: `filter[table] users değerlerinden biri olmalı: "sessions"`,
+		},
+		{
+			name:   "bool",
+			filter: Filter{Field: "status", Type: FilterBool},
+			value:  "false",
+			want:   Filter{Field: "status", Type: FilterBool, Values: []string{"false"}},
+		},
+		{
+			name:    "geçersiz bool",
+			filter:  Filter{Field: "status", Type: FilterBool},
+			value:   "evet",
+			want:    Filter{Field: "status", Type: FilterBool},
+			wantErr: `filter[status] true/false olmalı: "evet"`,
+		},
+		{
+			name:   "aralık başlangıcı",
+			filter: Filter{Field: "id", Type: FilterRange},
+			part:   "from", value: "10",
+			want: Filter{Field: "id", Type: FilterRange, From: "10"},
+		},
+		{
+			name:    "aralık ucu eksik",
+			filter:  Filter{Field: "id", Type: FilterRange},
+			value:   "10",
+			want:    Filter{Field: "id", Type: FilterRange},
+			wantErr: "filter[id] için [from] veya [to] belirtilmeli",
+		},
+		{
+			name:   "geçersiz sayı aralığı",
+			filter: Filter{Field: "id", Type: FilterRange},
+			part:   "to", value: "on",
+			want:    Filter{Field: "id", Type: FilterRange},
+			wantErr: `filter[id][to] geçersiz: "on"`,
+		},
+		{
+			name:   "tarih aralığı",
+			filter: Filter{Field: "created_at", Type: FilterDateRange},
+			part:   "to", value: "2024-02-29",
+			want: Filter{Field: "created_at", Type: FilterDateRange, To: "2024-02-29"},
+		},
+		{
+			name:   "geçersiz tarih",
+			filter: Filter{Field: "created_at", Type: FilterDateRange},
+			part:   "from", value: "2023-02-29",
+			want:    Filter{Field: "created_at", Type: FilterDateRange},
+			wantErr: `filter[created_at][from] geçersiz: "2023-02-29"`,
+		},
+		{
+			name:   "aralık desteklemeyen filtre",
+			filter: Filter{Field: "name", Type: FilterLike},
+			part:   "from", value: "a",
+			want:    Filter{Field: "name", Type: FilterLike},
+			wantErr: "filter[name] aralık desteklemiyor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			err := filter.set(tt.part, tt.value, tt.allowed)
+			checkErr(t, err, tt.wantErr)
+			if !reflect.DeepEqual(filter, tt.want) {
+				t.Errorf("filter = %+v, want %+v", filter, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	params, err := Parse(map[string]string{
+		"filter[type]":             "dashboard,root",
+		"filter[status]":           "true",
+		"filter[id]":               "5",
+		"filter[id][to]":           "9",
+		"filter[created_at][from]": "2024-01-01",
+		"filter[password]":         "x",
+		"filter[name]":             "   ",
+		"filter[name][x]":          "y",
+	}, testSpec())
+
+	for _, want := range []string{
+		`filter[type] dashboard, panel değerlerinden biri olmalı: "root"`,
+		"filter[id] için [from] veya [to] belirtilmeli",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("hata %q içermiyor: %v", want, err)
+		}
+	}
+
+	want := map[string]Filter{
+		"status":     {Field: "status", Type: FilterBool, Values: []string{"true"}},
+		"id":         {Field: "id", Type: FilterRange, To: "9"},
+		"created_at": {Field: "created_at", Type: FilterDateRange, From: "2024-01-01"},
+	}
+	if !reflect.DeepEqual(params.Filters, want) {
+		t.Errorf("Filters:\n got  %+v\n want %+v", params.Filters, want)
+	}
+}
+
+func TestQueryRoundTrip(t *testing.T) {
+	tests := []map[string]string{
+		{},
+		{"q": "İstanbul", "sort": "-name,account", "page": "2", "perPage": "50"},
+		{"filter[type]": "dashboard,panel", "filter[status]": "false", "filter[id][from]": "1", "filter[id][to]": "2"},
+		{"filter[created_at][to]": "2024-12-31", "filter[record_id]": "7", "cursor": ""},
+		{"cursor": "abc", "sort": "name"},
+	}
+	for _, queries := range tests {
+		first, err := Parse(queries, testSpec())
+		if err != nil {
+			t.Fatalf("Parse(%v): %v", queries, err)
+		}
+		second, err := Parse(flatten(first.Query()), testSpec())
+		if err != nil {
+			t.Fatalf("Parse(Query()) %v: %v", queries, err)
+		}
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("Query() gidiş-dönüşü farklı:\n ilk  %+v\n sonra %+v", first, second)
+		}
+	}
+}
+
+func TestQueryOmitsDefaults(t *testing.T) {
+	params, err := Parse(map[string]string{"page": "1", "perPage": "20"}, testSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := params.Query().Encode(); got != "" {
+		t.Errorf("Query() = %q, boş bekleniyordu", got)
+	}
+}
+
+func TestURL(t *testing.T) {
+	params, err := Parse(map[string]string{"q": "ali", "page": "3", "filter[status]": "true"}, testSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, key string
+		value     interface{}
+		want      string
+	}{
+		{"sayfa değişir", "page", 4, "?filter%5Bstatus%5D=true&page=4&q=ali"},
+		{"filtre değişince ilk sayfa", "q", "veli", "?filter%5Bstatus%5D=true&q=veli"},
+		{"boş değer silinir", "q", "", "?filter%5Bstatus%5D=true"},
+	}
+	for _, tt := range tests {
+		if got := params.URL(tt.key, tt.value); got != tt.want {
+			t.Errorf("%s: URL = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	params.UseCursor = true
+	params.Cursor = "abc"
+	if got, want := params.URL("sort", "name"), "?cursor=&filter%5Bstatus%5D=true&q=ali&sort=name"; got != want {
+		t.Errorf("imleçli URL = %q, want %q", got, want)
+	}
+	if got, want := params.URL("cursor", "def"), "?cursor=def&filter%5Bstatus%5D=true&q=ali"; got != want {
+		t.Errorf("imleç URL = %q, want %q", got, want)
+	}
+}
+
+func TestSortURL(t *testing.T) {
+	tests := []struct {
+		sort, field, want string
+	}{
+		{"", "name", "?sort=name"},
+		{"name", "name", "?sort=-name"},
+		{"-name", "name", "?sort=name"},
+		{"account,name", "name", "?sort=name"},
+		{"", "id", "?sort=id"},
+	}
+	for _, tt := range tests {
+		params, err := Parse(map[string]string{"sort": tt.sort, "page": "2"}, testSpec())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := params.SortURL(tt.field); got != tt.want {
+			t.Errorf("sort=%q SortURL(%q) = %q, want %q", tt.sort, tt.field, got, tt.want)
+		}
+	}
+}
+
+func flatten(values url.Values) map[string]string {
+	queries := make(map[string]string, len(values))
+	for key := range values {
+		queries[key] = values.Get(key)
+	}
+	return queries
+}
+
+func checkErr(t *testing.T, err error, want string) {
+	t.Helper()
+	if want == "" {
+		if err != nil {
+			t.Errorf("beklenmeyen hata: %v", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("hata = %v, %q bekleniyordu", err, want)
+	}
+}
@@ -0,0 +1,72 @@
+package queryparams
+
+type FilterType string
+
+const (
+	FilterEq        FilterType = "eq"
+	FilterIn        FilterType = "in"
+	FilterRange     FilterType = "range"
+	FilterBool      FilterType = "bool"
+	FilterDateRange FilterType = "date_range"
+	FilterLike      FilterType = "like"
+)
+
+const DateLayout = "2006-01-02"
+
+// CountMode listelemede toplam kayıt sayısının nasıl hesaplanacağını belirler.
+// Çok büyük tablolarda COUNT(*) yavaş olduğundan tahmin (pg_class.reltuples)
+// veya hiç saymama seçilebilir.
+type CountMode string
+
+const (
+	CountExact    CountMode = "exact"
+	CountEstimate CountMode = "estimate"
+	CountNone     CountMode = "none"
+)
+
+// FilterSpec query string'deki filter[<ad>] parametresinin hangi kolona ve
+// hangi operatörle uygulanacağını tanımlar. Values boş değilse eq ve in
+// filtreleri yalnızca bu değerleri kabul eder.
+type FilterSpec struct {
+	Column string
+	Type   FilterType
+	Values []string
+}
+
+// ListSpec bir modelin listeleme sorgularında izin verilen filtre, sıralama ve
+// arama kolonlarını tanımlar. Kullanıcıdan gelen alan adları yalnızca bu
+// tanımlar üzerinden kolona çevrilir; tanımsız alanlar yok sayılır.
+type ListSpec struct {
+	Filters       map[string]FilterSpec
+	Sorts         map[string]string
+	SearchColumns []string
+	DefaultSort   string
+	CountMode     CountMode
+
+	// SearchFuzzy aramada kelimelerin küçük yazım hatalarıyla da eşleşmesini
+	// sağlar. SearchRanked kullanıcı sıralama seçmediğinde arama sonuçlarını
+	// benzerliğe göre sıralar. İkisi de SearchColumns üzerinde trigram
+	// index'i gerektirir (bkz. migrations.MigrateSearchIndexes).
+	SearchFuzzy  bool
+	SearchRanked bool
+}
+
+// ListSpecProvider listelenebilir modellerin uyguladığı arayüzdür.
+type ListSpecProvider interface {
+	ListSpec() ListSpec
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Filter çözümlenmiş bir filtre değeridir. eq, bool ve like için Values[0],
+// in için tüm Values, range ve date_range için From/To kullanılır.
+type Filter struct {
+	Field  string
+	Type   FilterType
+	Values []string
+	From   string
+	To     string
+}
@@ -3,6 +3,7 @@ package renderer
 import (
 	"net/http"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
@@ -10,54 +11,44 @@ import (
 )
 
 const (
-	CsrfTokenKey        = "CsrfToken"
+	CsrfTokenKey = "CsrfToken"
+	FlashKey     = "Flash"
+	FormDataKey  = "FormData"
+	LocaleKey    = "Locale"
+
+	// FlashSuccessKeyView ve FlashErrorKeyView handler'ın render verisinde
+	// aynı istekte göstermek istediği mesajı verdiği anahtarlardır; mesaj
+	// önceki istekten kalan flash mesajlarının ardına eklenir.
 	FlashSuccessKeyView = "Success"
 	FlashErrorKeyView   = "Error"
-	FormDataKey         = "FormData"
 )
 
 func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 	renderData := make(fiber.Map)
 
 	renderData[CsrfTokenKey] = c.Locals("csrf")
+	renderData[LocaleKey] = i18n.From(c)
 
-	flashData, flashErr := flashmessages.GetFlashMessages(c)
+	flash, flashErr := flashmessages.Pop(c)
 	if flashErr != nil {
 		log.Warn("Render helper: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
-	renderData[FlashSuccessKeyView] = flashData.Success
-
-	var handlerError string
-	if data == nil {
-		data = fiber.Map{}
-	}
-
-	if errVal, ok := data[FlashErrorKeyView]; ok {
-		if errStr, okStr := errVal.(string); okStr {
-			handlerError = errStr
-		}
-	}
 
 	for key, value := range data {
-		renderData[key] = value
-	}
-
-	combinedError := flashData.Error
-	if handlerError != "" {
-		if combinedError != "" {
-			combinedError += " | " + handlerError
-		} else {
-			combinedError = handlerError
-		}
-	}
-
-	if combinedError != "" {
-		renderData[FlashErrorKeyView] = combinedError
-	} else {
-		if _, exists := renderData[FlashErrorKeyView]; exists {
-			delete(renderData, FlashErrorKeyView)
+		switch key {
+		case FlashSuccessKeyView:
+			if text, ok := value.(string); ok {
+				flash.Add(flashmessages.Message{Level: flashmessages.LevelSuccess, Text: text})
+			}
+		case FlashErrorKeyView:
+			if text, ok := value.(string); ok {
+				flash.Add(flashmessages.Message{Level: flashmessages.LevelError, Text: text, Dismissible: true})
+			}
+		default:
+			renderData[key] = value
 		}
 	}
+	renderData[FlashKey] = flash
 
 	return renderData
 }
@@ -7,14 +7,18 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
-var store *session.Store
+const storeLocalsKey = "session"
 
-func InitializeSessionStore(s *session.Store) {
-	store = s
+func Middleware(store *session.Store) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(storeLocalsKey, store)
+		return c.Next()
+	}
 }
 
 func SessionStart(c *fiber.Ctx) (*session.Session, error) {
-	if store == nil {
+	store, ok := c.Locals(storeLocalsKey).(*session.Store)
+	if !ok || store == nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, "session store not initialized")
 	}
 	return store.Get(c)
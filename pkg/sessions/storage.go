@@ -0,0 +1,77 @@
+package sessions
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrackedStorage oturum deposunu sarar ve yazılan oturum anahtarlarını son
+// kullanma zamanlarıyla birlikte tutar. Böylece alttaki depo anahtarları
+// saymayı desteklemese de süresi dolmamış oturum sayısı okunabilir. Sayım bu
+// sürecin yazdığı oturumları kapsar; paylaşılan bir depoda her örnek kendi
+// oturumlarını sayar.
+type TrackedStorage struct {
+	fiber.Storage
+
+	mu      sync.Mutex
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+func NewTrackedStorage(storage fiber.Storage) *TrackedStorage {
+	return &TrackedStorage{
+		Storage: storage,
+		expires: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+func (s *TrackedStorage) Set(key string, val []byte, exp time.Duration) error {
+	if err := s.Storage.Set(key, val, exp); err != nil {
+		return err
+	}
+	var expiresAt time.Time
+	if exp > 0 {
+		expiresAt = s.now().Add(exp)
+	}
+	s.mu.Lock()
+	s.expires[key] = expiresAt
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *TrackedStorage) Delete(key string) error {
+	if err := s.Storage.Delete(key); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.expires, key)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *TrackedStorage) Reset() error {
+	if err := s.Storage.Reset(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.expires = make(map[string]time.Time)
+	s.mu.Unlock()
+	return nil
+}
+
+// Count süresi dolmamış oturumların sayısını döner; süresi dolanları da
+// takipten çıkarır.
+func (s *TrackedStorage) Count() int {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, expiresAt := range s.expires {
+		if !expiresAt.IsZero() && !expiresAt.After(now) {
+			delete(s.expires, key)
+		}
+	}
+	return len(s.expires)
+}
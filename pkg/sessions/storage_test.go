@@ -0,0 +1,49 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+func TestTrackedStorageCount(t *testing.T) {
+	store := session.New()
+	tracked := NewTrackedStorage(store.Storage)
+	t.Cleanup(func() { _ = tracked.Close() })
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracked.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := tracked.Set(key, []byte("data"), time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tracked.Set("a", []byte("data"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracked.Set("short", []byte("data"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got := tracked.Count(); got != 4 {
+		t.Fatalf("Count = %d, 4 bekleniyordu", got)
+	}
+
+	if err := tracked.Delete("b"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Minute)
+	if got := tracked.Count(); got != 2 {
+		t.Fatalf("silme ve süre dolumu sonrası Count = %d, 2 bekleniyordu", got)
+	}
+	if data, err := tracked.Get("a"); err != nil || string(data) != "data" {
+		t.Fatalf("Get alttaki depoya gitmedi: %q, %v", data, err)
+	}
+
+	if err := tracked.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tracked.Count(); got != 0 {
+		t.Fatalf("Reset sonrası Count = %d, 0 bekleniyordu", got)
+	}
+}
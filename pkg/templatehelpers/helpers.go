@@ -1,9 +1,13 @@
 package templatehelpers
 
 import (
+	"fmt"
 	"net/url"
+	"strconv"
 	"text/template"
 	"time"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/turkishsearch"
 )
 
 func TemplateHelpers() template.FuncMap {
@@ -58,19 +62,38 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format(layout)
 		},
 
-		"FormatDate": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006")
-		},
+		// T, TN ve TErr çeviri yapar; ilk argüman sayfa verisindeki
+		// .Locale'dir: {{T $.Locale "users.title"}},
+		// {{TN $.Locale "list.showing" .Count}}, {{TErr $.Locale .Err}}.
+		"T":    (*i18n.Localizer).T,
+		"TN":   (*i18n.Localizer).N,
+		"TErr": (*i18n.Localizer).Error,
 
-		"FormatDateTime": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
+		// Tarihler isteğin dilindeki biçimle ve görüntüleyen kullanıcının saat
+		// diliminde yazılır (bkz. i18n.DateLayoutKey, i18n.ApplyUser).
+		"FormatDate":     (*i18n.Localizer).FormatDate,
+		"FormatDateTime": (*i18n.Localizer).FormatDateTime,
+
+		// FormatValue JSON'dan okunmuş değerleri (ör. audit değişiklikleri)
+		// okunabilir biçimde gösterir: boş değer "—", zaman damgası tarih-saat.
+		"FormatValue": func(l *i18n.Localizer, value interface{}) string {
+			switch v := value.(type) {
+			case nil:
+				return "—"
+			case float64:
+				return strconv.FormatFloat(v, 'f', -1, 64)
+			case string:
+				if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+					return l.FormatDateTimeSeconds(t)
+				}
+				return v
 			}
-			return t.Format("02.01.2006 15:04")
+			return fmt.Sprint(value)
 		},
+
+		// Highlight arama kelimelerini <mark> ile işaretler; eşleştirme
+		// listedeki aramayla aynı Türkçe katlama kurallarını kullanır.
+		"Highlight": turkishsearch.Highlight,
 	}
 	return fm
 }
@@ -0,0 +1,46 @@
+package turkishlocale
+
+import (
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// Tag uygulamanın metin karşılaştırma ve harf dönüşümlerinde kullandığı dildir.
+var Tag = language.Turkish
+
+// ToLower Türkçe kurallarla küçük harfe çevirir: "I" → "ı", "İ" → "i".
+// strings.ToLower "I"yı "i"ye çevirdiği için Türkçe metinde kullanılmamalıdır.
+func ToLower(s string) string {
+	return cases.Lower(Tag).String(s)
+}
+
+// ToUpper Türkçe kurallarla büyük harfe çevirir: "i" → "İ", "ı" → "I".
+func ToUpper(s string) string {
+	return cases.Upper(Tag).String(s)
+}
+
+// ToTitle her kelimenin ilk harfini Türkçe kurallarla büyütür
+// ("istanbul ılıca" → "İstanbul Ilıca").
+func ToTitle(s string) string {
+	return cases.Title(Tag).String(s)
+}
+
+// EqualFold a ile b'nin Türkçe büyük/küçük harf farkı gözetmeksizin eşit
+// olup olmadığını döner; "ISPARTA" ile "ısparta" eşittir, "isparta" değildir.
+func EqualFold(a, b string) bool {
+	return ToLower(a) == ToLower(b)
+}
+
+// LowerRune tek bir harfi Türkçe kurallarla küçültür. Harf sayısını
+// koruduğu için metindeki konumların değişmemesi gereken yerlerde
+// (ör. arama vurgulaması) ToLower yerine kullanılır.
+func LowerRune(r rune) rune {
+	return unicode.TurkishCase.ToLower(r)
+}
+
+// UpperRune tek bir harfi Türkçe kurallarla büyütür.
+func UpperRune(r rune) rune {
+	return unicode.TurkishCase.ToUpper(r)
+}
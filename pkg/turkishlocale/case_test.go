@@ -0,0 +1,93 @@
+package turkishlocale
+
+import "testing"
+
+func TestToLower(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"I", "ı"},
+		{"İ", "i"},
+		{"i", "i"},
+		{"ı", "ı"},
+		{"İSTANBUL", "istanbul"},
+		{"ISPARTA", "ısparta"},
+		{"IĞDIR İLİ", "ığdır ili"},
+		{"ÇAĞLA ÖZŞÜ", "çağla özşü"},
+		{"Straße", "straße"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ToLower(tt.in); got != tt.want {
+			t.Errorf("ToLower(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToUpper(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"i", "İ"},
+		{"ı", "I"},
+		{"I", "I"},
+		{"İ", "İ"},
+		{"istanbul", "İSTANBUL"},
+		{"ıstanbul", "ISTANBUL"},
+		{"çağla özşü", "ÇAĞLA ÖZŞÜ"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ToUpper(tt.in); got != tt.want {
+			t.Errorf("ToUpper(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToTitle(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"istanbul ılıca", "İstanbul Ilıca"},
+		{"İZMİR", "İzmir"},
+		{"ığdır", "Iğdır"},
+	}
+	for _, tt := range tests {
+		if got := ToTitle(tt.in); got != tt.want {
+			t.Errorf("ToTitle(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"ISPARTA", "ısparta", true},
+		{"İSTANBUL", "istanbul", true},
+		{"ISPARTA", "isparta", false},
+		{"İSTANBUL", "ıstanbul", false},
+		{"Çağla", "ÇAĞLA", true},
+	}
+	for _, tt := range tests {
+		if got := EqualFold(tt.a, tt.b); got != tt.want {
+			t.Errorf("EqualFold(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRuneCase(t *testing.T) {
+	tests := []struct{ upper, lower rune }{
+		{'I', 'ı'},
+		{'İ', 'i'},
+		{'Ç', 'ç'},
+		{'Ğ', 'ğ'},
+		{'Ö', 'ö'},
+		{'Ş', 'ş'},
+		{'Ü', 'ü'},
+		{'A', 'a'},
+	}
+	for _, tt := range tests {
+		if got := LowerRune(tt.upper); got != tt.lower {
+			t.Errorf("LowerRune(%q) = %q, want %q", tt.upper, got, tt.lower)
+		}
+		if got := UpperRune(tt.lower); got != tt.upper {
+			t.Errorf("UpperRune(%q) = %q, want %q", tt.lower, got, tt.upper)
+		}
+	}
+}
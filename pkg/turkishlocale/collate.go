@@ -0,0 +1,69 @@
+package turkishlocale
+
+import (
+	"sort"
+	"sync"
+
+	"golang.org/x/text/collate"
+)
+
+// PostgresCollation sıralanabilir metin kolonlarına uygulanan ICU
+// collation'ıdır; veritabanı sıralaması Compare ile aynı sonucu verir.
+const PostgresCollation = "tr-TR-x-icu"
+
+// collate.Collator eşzamanlı kullanıma uygun olmadığından havuzdan alınır.
+var collators = sync.Pool{
+	New: func() interface{} {
+		return collate.New(Tag)
+	},
+}
+
+// Compare a ile b'yi Türkçe alfabe sırasına göre karşılaştırır
+// (c < ç < d, g < ğ < h, ı < i, o < ö < p, s < ş < t, u < ü < v).
+// a önce geliyorsa negatif, sonra geliyorsa pozitif, eşitse 0 döner.
+func Compare(a, b string) int {
+	collator := collators.Get().(*collate.Collator)
+	defer collators.Put(collator)
+	return collator.CompareString(a, b)
+}
+
+func Less(a, b string) bool {
+	return Compare(a, b) < 0
+}
+
+// SortStrings values'ı Türkçe alfabe sırasına göre sıralar.
+func SortStrings(values []string) {
+	collator := collators.Get().(*collate.Collator)
+	defer collators.Put(collator)
+	collator.SortStrings(values)
+}
+
+// SortFunc items'ı key'in döndüğü metne göre Türkçe alfabe sırasıyla,
+// eşit olanların sırasını koruyarak sıralar.
+func SortFunc[T any](items []T, key func(item T) string) {
+	collator := collators.Get().(*collate.Collator)
+	defer collators.Put(collator)
+
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = key(item)
+	}
+	sort.Stable(&keyedSlice[T]{items: items, keys: keys, collator: collator})
+}
+
+type keyedSlice[T any] struct {
+	items    []T
+	keys     []string
+	collator *collate.Collator
+}
+
+func (s *keyedSlice[T]) Len() int { return len(s.items) }
+
+func (s *keyedSlice[T]) Less(i, j int) bool {
+	return s.collator.CompareString(s.keys[i], s.keys[j]) < 0
+}
+
+func (s *keyedSlice[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
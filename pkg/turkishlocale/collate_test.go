@@ -0,0 +1,62 @@
+package turkishlocale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"Çağla", "Zeynep"},
+		{"ı", "i"},
+		{"Ö", "P"},
+		{"c", "ç"},
+		{"ç", "d"},
+		{"g", "ğ"},
+		{"ğ", "h"},
+		{"o", "ö"},
+		{"s", "ş"},
+		{"ş", "t"},
+		{"u", "ü"},
+		{"ü", "v"},
+		{"Işık", "İnci"},
+		{"Can", "Çınar"},
+		{"Şule", "Tamer"},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got >= 0 {
+			t.Errorf("Compare(%q, %q) = %d, negatif bekleniyordu", tt.a, tt.b, got)
+		}
+		if got := Compare(tt.b, tt.a); got <= 0 {
+			t.Errorf("Compare(%q, %q) = %d, pozitif bekleniyordu", tt.b, tt.a, got)
+		}
+		if !Less(tt.a, tt.b) || Less(tt.b, tt.a) {
+			t.Errorf("Less(%q, %q) tutarsız", tt.a, tt.b)
+		}
+	}
+	if got := Compare("Çağla", "Çağla"); got != 0 {
+		t.Errorf("Compare(eşit) = %d, want 0", got)
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	values := []string{"Zeynep", "Ömer", "İpek", "Çağla", "Ilgaz", "Can", "Şule", "Osman", "Deniz", "Sevgi"}
+	SortStrings(values)
+	want := []string{"Can", "Çağla", "Deniz", "Ilgaz", "İpek", "Osman", "Ömer", "Sevgi", "Şule", "Zeynep"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("SortStrings = %q, want %q", values, want)
+	}
+}
+
+func TestSortFuncIsStable(t *testing.T) {
+	type item struct {
+		name string
+		id   int
+	}
+	items := []item{{"Ümit", 1}, {"Ali", 2}, {"Ümit", 3}, {"Ufuk", 4}, {"Ali", 5}}
+	SortFunc(items, func(i item) string { return i.name })
+	want := []item{{"Ali", 2}, {"Ali", 5}, {"Ufuk", 4}, {"Ümit", 1}, {"Ümit", 3}}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("SortFunc = %v, want %v", items, want)
+	}
+}
@@ -0,0 +1,66 @@
+package turkishsearch
+
+import (
+	"html"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// Highlight text'te query'nin kelimelerinin geçtiği yerleri <mark> ile
+// işaretler. Eşleştirme Fold ile yapılır ("istanbul" araması "İSTANBUL"u
+// vurgular); text HTML olarak kaçırılır.
+func Highlight(text, query string) template.HTML {
+	terms := Terms(query)
+	if text == "" || len(terms) == 0 {
+		return template.HTML(html.EscapeString(text))
+	}
+
+	original := []rune(text)
+	folded := []rune(Fold(text))
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, term := range terms {
+		termRunes := []rune(term)
+		for i := 0; i+len(termRunes) <= len(folded); {
+			if runesEqual(folded[i:i+len(termRunes)], termRunes) {
+				spans = append(spans, span{i, i + len(termRunes)})
+				i += len(termRunes)
+				continue
+			}
+			i++
+		}
+	}
+	if len(spans) == 0 {
+		return template.HTML(html.EscapeString(text))
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var builder strings.Builder
+	pos := 0
+	for _, s := range spans {
+		if s.end <= pos {
+			continue
+		}
+		if s.start < pos {
+			s.start = pos
+		}
+		builder.WriteString(html.EscapeString(string(original[pos:s.start])))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(string(original[s.start:s.end])))
+		builder.WriteString("</mark>")
+		pos = s.end
+	}
+	builder.WriteString(html.EscapeString(string(original[pos:])))
+	return template.HTML(builder.String())
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package turkishsearch
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name, text, query string
+		want              template.HTML
+	}{
+		{"büyük harfli İ", "İstanbul", "istanbul", "<mark>İstanbul</mark>"},
+		{"noktasız I", "IŞIK ışık", "isik", "<mark>IŞIK</mark> <mark>ışık</mark>"},
+		{"metnin ortasında", "Büyükçekmece", "cekmece", "Büyük<mark>çekmece</mark>"},
+		{"çok baytlı önek", "Çağla Öztürk", "ozturk", "Çağla <mark>Öztürk</mark>"},
+		{"birden fazla kelime", "Çağla Öztürk", "ÖZTÜRK çağla", "<mark>Çağla</mark> <mark>Öztürk</mark>"},
+		{"eşleşmeyen kısım kaçırılır", `Ali & "Şule" <b>`, "sule", `Ali &amp; &#34;<mark>Şule</mark>&#34; &lt;b&gt;`},
+		{"eşleşen kısım kaçırılır", "<İ>", "i", "&lt;<mark>İ</mark>&gt;"},
+		{"iç içe kelimeler", "İstanbul", "stan istan", "<mark>İstan</mark>bul"},
+		{"kısmen örtüşen kelimeler", "abcdef", "abc cde", "<mark>abc</mark><mark>de</mark>f"},
+		{"eşleşme yok", "<Ankara>", "izmir", "&lt;Ankara&gt;"},
+		{"boş arama", "<Ankara>", "  ", "&lt;Ankara&gt;"},
+		{"boş metin", "", "ankara", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.text, tt.query); got != tt.want {
+				t.Errorf("Highlight(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,42 +2,73 @@ package turkishsearch
 
 import (
 	"strings"
-	"unicode"
 )
 
-func normalize(str string) string {
-	replacements := map[rune]rune{
-		'ç': 'c', 'Ç': 'C',
-		'ğ': 'g', 'Ğ': 'G',
-		'ı': 'i', 'I': 'I',
-		'ö': 'o', 'Ö': 'O',
-		'ş': 's', 'Ş': 'S',
-		'ü': 'u', 'Ü': 'U',
+// foldFrom ve foldTo, Fold'un ve veritabanındaki tr_fold fonksiyonunun
+// uyguladığı harf eşlemesidir. İkisi aynı tablodan üretildiği için bellekteki
+// eşleştirme ve vurgulama SQL'deki aramayla aynı sonucu verir. Her harf tek
+// bir harfe eşlenir; böylece katlanmış metindeki konumlar orijinal metne
+// birebir karşılık gelir.
+const (
+	foldFrom = "İIıÇçĞğÖöŞşÜüÂâÎîÛûÁáÀàÄäÉéÈèÊêËëÍíÌìÏïÓóÒòÔôÚúÙùÑñ"
+	foldTo   = "iiiccggoossuuaaiiuuaaaaaaeeeeeeeeiiiiiioooooouuuunn"
+)
+
+var foldMap = func() map[rune]rune {
+	from, to := []rune(foldFrom), []rune(foldTo)
+	m := make(map[rune]rune, len(from))
+	for i, r := range from {
+		m[r] = to[i]
 	}
+	return m
+}()
 
+// Fold metni aramada karşılaştırılacak biçime getirir: tablodaki Türkçe ve
+// aksanlı harfleri ASCII karşılıklarına (İ→i, I→i, ş→s...), diğer ASCII
+// harfleri küçük harfe çevirir. Tabloda olmayan ASCII dışı harfler tr_fold'da
+// olduğu gibi değiştirilmez. Harf harf çalıştığından sonuç orijinal metinle
+// aynı sayıda harf içerir. Böylece "İSTANBUL", "istanbul" ve "Istanbul" aynı
+// sonuca katlanır.
+func Fold(s string) string {
 	var builder strings.Builder
-	for _, r := range str {
-		if repl, ok := replacements[r]; ok {
-			builder.WriteRune(unicode.ToLower(repl))
-		} else {
-			builder.WriteRune(unicode.ToLower(r))
-		}
+	builder.Grow(len(s))
+	for _, r := range s {
+		builder.WriteRune(foldRune(r))
 	}
 	return builder.String()
 }
 
-func MatchNormalized(text, keyword string) bool {
-	normText := normalize(text)
-	normKeyword := normalize(keyword)
-	return strings.Contains(normText, normKeyword)
+func foldRune(r rune) rune {
+	if folded, ok := foldMap[r]; ok {
+		return folded
+	}
+	if r >= 'A' && r <= 'Z' {
+		return r + ('a' - 'A')
+	}
+	return r
 }
 
-func SQLFilter(columnName, search string) (string, []interface{}) {
-	filterValue := "%" + strings.ToLower(search) + "%"
-
-	query := "unaccent(lower(" + columnName + ")) ILIKE unaccent($1)"
-
-	params := []interface{}{filterValue}
+// MatchNormalized keyword'deki her kelimenin text içinde, katlanmış halleriyle
+// geçip geçmediğini döner.
+func MatchNormalized(text, keyword string) bool {
+	folded := Fold(text)
+	for _, term := range Terms(keyword) {
+		if !strings.Contains(folded, term) {
+			return false
+		}
+	}
+	return true
+}
 
-	return query, params
+// Terms arama ifadesini katlanmış, tekrarsız kelimelere ayırır.
+func Terms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range strings.Fields(Fold(query)) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
 }
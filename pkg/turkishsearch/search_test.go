@@ -0,0 +1,80 @@
+package turkishsearch
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFoldTable(t *testing.T) {
+	from, to := []rune(foldFrom), []rune(foldTo)
+	if len(from) != len(to) {
+		t.Fatalf("foldFrom %d, foldTo %d harf; eşit olmalı", len(from), len(to))
+	}
+	seen := make(map[rune]bool)
+	for i, r := range from {
+		if seen[r] {
+			t.Errorf("%q eşleme tablosunda iki kez var", r)
+		}
+		seen[r] = true
+		if got, want := Fold(string(r)), string(to[i]); got != want {
+			t.Errorf("Fold(%q) = %q, want %q", r, got, want)
+		}
+		if to[i] >= utf8.RuneSelf {
+			t.Errorf("%q ASCII olmayan %q harfine katlanıyor", r, to[i])
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"İSTANBUL", "istanbul"},
+		{"Istanbul", "istanbul"},
+		{"ıstanbul", "istanbul"},
+		{"ÇAĞLA ÖZŞÜ", "cagla ozsu"},
+		{"Âdem Îmer Ûlkü", "adem imer ulku"},
+		{"José Müller", "jose muller"},
+		{"ABC xyz 123", "abc xyz 123"},
+		// tr_fold'daki lower COLLATE "C" yalnızca ASCII harfleri küçültür.
+		{"ŁÓDŹ Ωmega", "ŁodŹ Ωmega"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := Fold(tt.in)
+		if got != tt.want {
+			t.Errorf("Fold(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if utf8.RuneCountInString(got) != utf8.RuneCountInString(tt.in) {
+			t.Errorf("Fold(%q) harf sayısını değiştirdi", tt.in)
+		}
+	}
+}
+
+func TestMatchNormalized(t *testing.T) {
+	tests := []struct {
+		text, keyword string
+		want          bool
+	}{
+		{"İstanbul Üniversitesi", "istanbul universite", true},
+		{"İstanbul Üniversitesi", "ISTANBUL", true},
+		{"İstanbul Üniversitesi", "ankara", false},
+		{"Çağla Öztürk", "ozturk cagla", true},
+		{"Çağla", "", true},
+	}
+	for _, tt := range tests {
+		if got := MatchNormalized(tt.text, tt.keyword); got != tt.want {
+			t.Errorf("MatchNormalized(%q, %q) = %v, want %v", tt.text, tt.keyword, got, tt.want)
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	got := Terms("  İzmir  IZMIR izmir Çeşme ")
+	want := []string{"izmir", "cesme"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Terms = %q, want %q", got, want)
+	}
+	if got := Terms("   "); got != nil {
+		t.Errorf("Terms(boş) = %q, nil bekleniyordu", got)
+	}
+}
@@ -0,0 +1,95 @@
+package turkishsearch
+
+import (
+	"strings"
+)
+
+// FunctionName aramada kolonlara uygulanan veritabanı fonksiyonudur.
+const FunctionName = "tr_fold"
+
+// CreateFunctionSQL Fold'un veritabanı karşılığını oluşturur. lower "C"
+// collation'ıyla çağrıldığından yalnızca ASCII harfleri küçültür ve sonuç
+// veritabanının locale ayarından bağımsızdır; bu yüzden fonksiyon IMMUTABLE
+// işaretlenebilir ve tr_fold(kolon) üzerinde ifade index'i oluşturulabilir.
+// (unaccent STABLE olduğundan index ifadesinde kullanılamaz.)
+func CreateFunctionSQL() string {
+	return `CREATE OR REPLACE FUNCTION ` + FunctionName + `(text) RETURNS text
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+AS $$ SELECT lower(translate($1, '` + foldFrom + `', '` + foldTo + `') COLLATE "C") $$`
+}
+
+// IndexSQL column için tr_fold ifadesi üzerinde bir pg_trgm GIN index'i
+// oluşturan komutu döner. Bu index hem LIKE '%...%' aramalarını hem de
+// benzerlik (<%) operatörünü destekler.
+func IndexSQL(table, column string) string {
+	return `CREATE INDEX IF NOT EXISTS ` + IndexName(table, column) + ` ON ` + table +
+		` USING gin (` + FunctionName + `(` + column + `) gin_trgm_ops)`
+}
+
+func IndexName(table, column string) string {
+	return "idx_" + table + "_" + column + "_trgm"
+}
+
+func folded(column string) string {
+	return FunctionName + "(" + column + ")"
+}
+
+// SQLFilter column'un search'ü içerdiği satırları seçen koşulu döner.
+// Aranan değer Go tarafında katlanır; kolon tarafı tr_fold index'ini kullanır.
+func SQLFilter(column, search string) (string, []interface{}) {
+	return folded(column) + " LIKE ?", []interface{}{containsPattern(Fold(strings.TrimSpace(search)))}
+}
+
+// Condition çok kolonlu arama koşulunu döner: query'deki her kelime
+// kolonlardan en az birinde geçmelidir. fuzzy true ise bir kelime, içerilmese
+// bile pg_trgm'nin kelime benzerliği eşiğini (pg_trgm.word_similarity_threshold)
+// geçtiğinde de eşleşir; bu küçük yazım hatalarını tolere eder.
+func Condition(columns []string, query string, fuzzy bool) (string, []interface{}) {
+	terms := Terms(query)
+	if len(terms) == 0 || len(columns) == 0 {
+		return "", nil
+	}
+
+	var (
+		groups []string
+		args   []interface{}
+	)
+	for _, term := range terms {
+		alternatives := make([]string, 0, len(columns)*2)
+		for _, column := range columns {
+			alternatives = append(alternatives, folded(column)+" LIKE ?")
+			args = append(args, containsPattern(term))
+			if fuzzy {
+				alternatives = append(alternatives, "? <% "+folded(column))
+				args = append(args, term)
+			}
+		}
+		groups = append(groups, "("+strings.Join(alternatives, " OR ")+")")
+	}
+	return "(" + strings.Join(groups, " AND ") + ")", args
+}
+
+// RankExpression query'nin kolonlara en yüksek kelime benzerliğini veren
+// ifadeyi döner; sonuçları ilgiye göre sıralamak için kullanılır.
+func RankExpression(columns []string, query string) (string, []interface{}) {
+	term := strings.Join(Terms(query), " ")
+	if term == "" || len(columns) == 0 {
+		return "", nil
+	}
+	parts := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		parts[i] = "word_similarity(?, " + folded(column) + ")"
+		args[i] = term
+	}
+	if len(parts) == 1 {
+		return parts[0], args
+	}
+	return "GREATEST(" + strings.Join(parts, ", ") + ")", args
+}
+
+// containsPattern LIKE joker karakterlerini kaçırıp term'i %...% içine alır.
+func containsPattern(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(term) + "%"
+}
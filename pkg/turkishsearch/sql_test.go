@@ -0,0 +1,116 @@
+package turkishsearch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		query   string
+		fuzzy   bool
+		want    string
+		args    []interface{}
+	}{
+		{
+			name:    "tek kelime",
+			columns: []string{"name", "account"},
+			query:   "  ÇAĞLA ",
+			want:    "((tr_fold(name) LIKE ? OR tr_fold(account) LIKE ?))",
+			args:    []interface{}{"%cagla%", "%cagla%"},
+		},
+		{
+			name:    "birden fazla kelime",
+			columns: []string{"name"},
+			query:   "İstanbul istanbul Üsküdar",
+			want:    "((tr_fold(name) LIKE ?) AND (tr_fold(name) LIKE ?))",
+			args:    []interface{}{"%istanbul%", "%uskudar%"},
+		},
+		{
+			name:    "bulanık",
+			columns: []string{"name", "account"},
+			query:   "ışık",
+			fuzzy:   true,
+			want:    "((tr_fold(name) LIKE ? OR ? <% tr_fold(name) OR tr_fold(account) LIKE ? OR ? <% tr_fold(account)))",
+			args:    []interface{}{"%isik%", "isik", "%isik%", "isik"},
+		},
+		{
+			name:    "LIKE jokerleri kaçırılır",
+			columns: []string{"name"},
+			query:   `%_\`,
+			want:    "((tr_fold(name) LIKE ?))",
+			args:    []interface{}{`%\%\_\\%`},
+		},
+		{name: "boş arama", columns: []string{"name"}, query: "   "},
+		{name: "kolon yok", query: "ali"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := Condition(tt.columns, tt.query, tt.fuzzy)
+			if got != tt.want {
+				t.Errorf("koşul:\n got  %s\n want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %q, want %q", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestRankExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		query   string
+		want    string
+		args    []interface{}
+	}{
+		{"tek kolon", []string{"name"}, "Çağla", "word_similarity(?, tr_fold(name))", []interface{}{"cagla"}},
+		{
+			"birden fazla kolon", []string{"name", "account"}, " ÖZTÜRK  çağla ",
+			"GREATEST(word_similarity(?, tr_fold(name)), word_similarity(?, tr_fold(account)))",
+			[]interface{}{"ozturk cagla", "ozturk cagla"},
+		},
+		{"boş arama", []string{"name"}, "", "", nil},
+		{"kolon yok", nil, "ali", "", nil},
+	}
+	for _, tt := range tests {
+		got, args := RankExpression(tt.columns, tt.query)
+		if got != tt.want || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: RankExpression = %s %q, want %s %q", tt.name, got, args, tt.want, tt.args)
+		}
+	}
+}
+
+func TestSQLFilter(t *testing.T) {
+	got, args := SQLFilter("account", "  Şule_1 ")
+	if got != "tr_fold(account) LIKE ?" || !reflect.DeepEqual(args, []interface{}{`%sule\_1%`}) {
+		t.Errorf("SQLFilter = %s %q", got, args)
+	}
+}
+
+func TestCreateFunctionSQL(t *testing.T) {
+	sql := CreateFunctionSQL()
+	for _, want := range []string{
+		"CREATE OR REPLACE FUNCTION tr_fold(text)",
+		"IMMUTABLE",
+		"lower(translate($1, '" + foldFrom + "', '" + foldTo + "') COLLATE \"C\")",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("fonksiyon %q içermiyor:\n%s", want, sql)
+		}
+	}
+	if strings.ContainsRune(foldFrom+foldTo, '\'') {
+		t.Error("eşleme tablosu SQL metnini bozan tırnak içeriyor")
+	}
+}
+
+func TestIndexSQL(t *testing.T) {
+	want := "CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (tr_fold(name) gin_trgm_ops)"
+	if got := IndexSQL("users", "name"); got != want {
+		t.Errorf("IndexSQL:\n got  %s\n want %s", got, want)
+	}
+}
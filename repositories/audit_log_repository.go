@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+
+	"gorm.io/gorm"
+)
+
+// IAuditLogRepository audit kayıtlarını yalnızca okur; kayıtlar pkg/audit
+// eklentisi tarafından yazılır.
+type IAuditLogRepository interface {
+	List(ctx context.Context, params queryparams.ListParams) ([]models.AuditLog, queryparams.PaginationMeta, error)
+	Stream(ctx context.Context, params queryparams.ListParams, fn func(item *models.AuditLog) error) error
+}
+
+type AuditLogRepository struct {
+	BaseRepository[models.AuditLog]
+}
+
+func NewAuditLogRepository(db *gorm.DB) IAuditLogRepository {
+	return &AuditLogRepository{BaseRepository: BaseRepository[models.AuditLog]{db: db}}
+}
+
+var _ IAuditLogRepository = (*AuditLogRepository)(nil)
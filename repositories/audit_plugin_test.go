@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/audit"
+	"zatrano/pkg/logs"
+
+	"gorm.io/gorm"
+)
+
+const auditPasswordHash = "$2a$10$gizlisifreozeti"
+
+var auditUserColumns = []string{"id", "name", "account", "password", "status", "type", "version", "deleted_at", "deleted_by", "updated_by", "updated_at"}
+
+// auditedEntry fakeDB'ye gelen audit_logs INSERT'inden çözülen bir kayıttır.
+type auditedEntry struct {
+	Action    string
+	RecordID  string
+	ActorID   string
+	RequestID string
+	IP        string
+	Changes   models.AuditChanges
+}
+
+// newAuditedFake audit eklentisi kayıtlı bir fakeDB döner. users tablosundan
+// yapılan okumalar o ana kadar çalışan UPDATE sayısına göre states'ten sıradaki
+// satırı alır; böylece eklentinin işlem öncesi ve sonrası okumaları farklı
+// değerler görür.
+func newAuditedFake(t *testing.T, states ...[]driver.Value) (*gorm.DB, *fakeDB) {
+	t.Helper()
+	var fake *fakeDB
+	db, fake := newFakeGorm(t, func(query string) fakeRows {
+		switch {
+		case strings.HasPrefix(query, "SELECT count(*)"):
+			return fakeRows{Columns: []string{"count"}, Rows: [][]driver.Value{{int64(1)}}}
+		case strings.HasPrefix(query, `SELECT * FROM "users"`):
+			state := len(fake.Queries("UPDATE"))
+			if state >= len(states) {
+				state = len(states) - 1
+			}
+			return fakeRows{Columns: auditUserColumns, Rows: [][]driver.Value{states[state]}}
+		case strings.HasPrefix(query, "INSERT"):
+			return fakeRows{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(7)}}}
+		}
+		return fakeRows{}
+	})
+	if err := db.Use(audit.NewPlugin()); err != nil {
+		t.Fatal(err)
+	}
+	return db, fake
+}
+
+func auditUserRow(name string, version int64, deletedAt interface{}, deletedBy interface{}) []driver.Value {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []driver.Value{int64(7), name, "ayse", auditPasswordHash, true, "panel", version, deletedAt, deletedBy, int64(5), updatedAt.Add(time.Duration(version) * time.Minute)}
+}
+
+func auditContext() context.Context {
+	ctx := context.WithValue(context.Background(), "user_id", uint(5))
+	ctx = context.WithValue(ctx, logs.RequestIDContextKey, "req-42")
+	return context.WithValue(ctx, logs.ClientIPContextKey, "10.0.0.9")
+}
+
+// auditEntries kayıtlı audit_logs INSERT'lerini kolon adlarına göre çözer.
+func auditEntries(t *testing.T, fake *fakeDB) []auditedEntry {
+	t.Helper()
+	var entries []auditedEntry
+	for _, q := range fake.Queries(`INSERT INTO "audit_logs"`) {
+		columns := insertColumns(q.SQL)
+		for start := 0; start+len(columns) <= len(q.Args); start += len(columns) {
+			values := map[string]interface{}{}
+			for i, column := range columns {
+				values[column] = q.Args[start+i]
+			}
+			entry := auditedEntry{
+				Action:    fmt.Sprint(values["action"]),
+				RecordID:  fmt.Sprint(values["record_id"]),
+				ActorID:   fmt.Sprint(values["actor_id"]),
+				RequestID: fmt.Sprint(values["request_id"]),
+				IP:        fmt.Sprint(values["ip"]),
+			}
+			if err := json.Unmarshal([]byte(fmt.Sprint(values["changes"])), &entry.Changes); err != nil {
+				t.Fatalf("changes çözülemedi: %v (%v)", err, values["changes"])
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func insertColumns(query string) []string {
+	list := query[strings.Index(query, "(")+1 : strings.Index(query, ")")]
+	columns := strings.Split(list, ",")
+	for i, column := range columns {
+		columns[i] = strings.Trim(column, `" `)
+	}
+	return columns
+}
+
+// assertNoPassword şifrenin ve özetinin audit_logs ile user_versions
+// INSERT'lerinin hiçbir parametresinde bulunmadığını doğrular.
+func assertNoPassword(t *testing.T, fake *fakeDB) {
+	t.Helper()
+	for _, prefix := range []string{`INSERT INTO "audit_logs"`, `INSERT INTO "user_versions"`} {
+		for _, q := range fake.Queries(prefix) {
+			if strings.Contains(q.SQL, "password") {
+				t.Errorf("şifre kolonu yazılıyor: %s", q.SQL)
+			}
+			for _, arg := range q.Args {
+				if text := fmt.Sprint(arg); strings.Contains(text, auditPasswordHash) || strings.Contains(text, `"password"`) {
+					t.Errorf("%s şifreyi içeriyor: %s", prefix, text)
+				}
+			}
+		}
+	}
+}
+
+func assertActor(t *testing.T, entry auditedEntry) {
+	t.Helper()
+	if entry.ActorID != "5" || entry.RequestID != "req-42" || entry.IP != "10.0.0.9" {
+		t.Errorf("işlemi yapan bilgisi = actor %s, request %s, ip %s; 5, req-42, 10.0.0.9 bekleniyordu",
+			entry.ActorID, entry.RequestID, entry.IP)
+	}
+}
+
+func TestAuditPluginCreate(t *testing.T) {
+	db, fake := newAuditedFake(t, auditUserRow("Ayşe", 1, nil, nil))
+	user := &models.User{Name: "Ayşe", Account: "ayse", Password: auditPasswordHash, Type: models.Panel}
+
+	if err := NewUserRepository(db).Create(auditContext(), user); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := auditEntries(t, fake)
+	if len(entries) != 1 {
+		t.Fatalf("%d audit kaydı yazıldı, 1 bekleniyordu", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != string(models.AuditActionCreate) || entry.RecordID != "7" {
+		t.Errorf("kayıt = %s #%s, create #7 bekleniyordu", entry.Action, entry.RecordID)
+	}
+	assertActor(t, entry)
+	if change, ok := entry.Changes["name"]; !ok || change.Old != nil || change.New != "Ayşe" {
+		t.Errorf("name değişikliği = %+v, yalnızca yeni değer bekleniyordu", change)
+	}
+	if _, ok := entry.Changes["deleted_at"]; ok {
+		t.Error("boş kolonlar oluşturma kaydına yazılmamalı")
+	}
+	if _, ok := entry.Changes["password"]; ok {
+		t.Error("şifre oluşturma kaydına yazıldı")
+	}
+	if versions := fake.Queries(`INSERT INTO "user_versions"`); len(versions) != 1 {
+		t.Errorf("%d sürüm kaydı yazıldı, 1 bekleniyordu", len(versions))
+	}
+	assertNoPassword(t, fake)
+}
+
+func TestAuditPluginUpdate(t *testing.T) {
+	newHash := "$2a$10$yenisifreozeti"
+	updated := auditUserRow("Ayşe Yılmaz", 2, nil, nil)
+	updated[3] = newHash
+	db, fake := newAuditedFake(t, auditUserRow("Ayşe", 1, nil, nil), updated)
+	repo := NewUserRepository(db)
+	data := map[string]interface{}{"name": "Ayşe Yılmaz", "password": newHash}
+
+	if err := repo.UpdateUserFields(auditContext(), 7, 1, data, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := auditEntries(t, fake)
+	if len(entries) != 1 {
+		t.Fatalf("%d audit kaydı yazıldı, 1 bekleniyordu", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != string(models.AuditActionUpdate) {
+		t.Errorf("işlem = %s, update bekleniyordu", entry.Action)
+	}
+	assertActor(t, entry)
+	want := models.AuditChanges{"name": {Old: "Ayşe", New: "Ayşe Yılmaz"}}
+	if got, _ := json.Marshal(entry.Changes); string(got) != mustJSON(t, want) {
+		t.Errorf("değişiklikler = %s, %s bekleniyordu", got, mustJSON(t, want))
+	}
+	if versions := fake.Queries(`INSERT INTO "user_versions"`); len(versions) != 1 {
+		t.Errorf("%d sürüm kaydı yazıldı, 1 bekleniyordu", len(versions))
+	}
+	assertNoPassword(t, fake)
+	for _, prefix := range []string{`INSERT INTO "audit_logs"`, `INSERT INTO "user_versions"`} {
+		for _, q := range fake.Queries(prefix) {
+			for _, arg := range q.Args {
+				if strings.Contains(fmt.Sprint(arg), newHash) {
+					t.Errorf("yeni şifre %s kaydına yazıldı: %v", prefix, arg)
+				}
+			}
+		}
+	}
+}
+
+func TestAuditPluginSoftDelete(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+	db, fake := newAuditedFake(t,
+		auditUserRow("Ayşe", 1, nil, nil),
+		auditUserRow("Ayşe", 1, nil, int64(5)),
+		auditUserRow("Ayşe", 1, deletedAt, int64(5)),
+	)
+
+	if err := NewUserRepository(db).Delete(auditContext(), 7); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := auditEntries(t, fake)
+	if len(entries) != 2 {
+		t.Fatalf("%d audit kaydı yazıldı, 2 bekleniyordu", len(entries))
+	}
+	marked, deleted := entries[0], entries[1]
+	if marked.Action != string(models.AuditActionUpdate) || marked.Changes["deleted_by"].New != float64(5) {
+		t.Errorf("deleted_by kaydı = %s %+v", marked.Action, marked.Changes)
+	}
+	if deleted.Action != string(models.AuditActionDelete) {
+		t.Errorf("işlem = %s, delete bekleniyordu", deleted.Action)
+	}
+	if change, ok := deleted.Changes["deleted_at"]; !ok || change.Old != nil || change.New == nil {
+		t.Errorf("deleted_at değişikliği = %+v", deleted.Changes)
+	}
+	if len(deleted.Changes) != 1 {
+		t.Errorf("silme kaydında yalnızca deleted_at bekleniyordu: %+v", deleted.Changes)
+	}
+	for _, entry := range entries {
+		assertActor(t, entry)
+	}
+	if versions := fake.Queries(`INSERT INTO "user_versions"`); len(versions) != 0 {
+		t.Errorf("sürümü değişmeyen silme sürüm kaydı yazdı: %d", len(versions))
+	}
+	assertNoPassword(t, fake)
+}
+
+func TestAuditPluginWithoutActor(t *testing.T) {
+	db, fake := newAuditedFake(t, auditUserRow("Ayşe", 1, nil, nil))
+	user := &models.User{Name: "Ayşe", Account: "ayse", Password: auditPasswordHash, Type: models.Panel}
+
+	if err := NewUserRepository(db).Create(models.WithSystemActor(context.Background()), user); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := auditEntries(t, fake)
+	if len(entries) != 1 {
+		t.Fatalf("%d audit kaydı yazıldı, 1 bekleniyordu", len(entries))
+	}
+	if entries[0].ActorID != "<nil>" || entries[0].RequestID != "" {
+		t.Errorf("sistem işleminde actor = %s, request = %q; boş bekleniyordu", entries[0].ActorID, entries[0].RequestID)
+	}
+}
+
+func mustJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
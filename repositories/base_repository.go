@@ -3,18 +3,35 @@ package repositories
 import (
 	"context"
 	"errors"
+	"reflect"
+	"time"
+	"zatrano/models"
 	"zatrano/pkg/customerrors"
+	"zatrano/pkg/queryparams"
 
 	"gorm.io/gorm"
 )
 
 type IBaseRepository[T any] interface {
-	GetAll() ([]T, error)
-	GetByID(id uint) (*T, error)
-	GetCount() (int64, error)
+	GetAll(ctx context.Context) ([]T, error)
+	GetByID(ctx context.Context, id uint) (*T, error)
+	GetCount(ctx context.Context) (int64, error)
+	List(ctx context.Context, params queryparams.ListParams) ([]T, queryparams.PaginationMeta, error)
 	Create(ctx context.Context, entity *T) error
 	Update(ctx context.Context, id uint, data map[string]interface{}) error
+	UpdateVersioned(ctx context.Context, id uint, version uint, data map[string]interface{}) error
 	Delete(ctx context.Context, id uint) error
+	ListTrashed(ctx context.Context, params queryparams.ListParams) ([]T, queryparams.PaginationMeta, error)
+	Stream(ctx context.Context, params queryparams.ListParams, fn func(item *T) error) error
+	StreamTrashed(ctx context.Context, params queryparams.ListParams, fn func(item *T) error) error
+	Restore(ctx context.Context, id uint) error
+	ForceDelete(ctx context.Context, id uint) error
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
+	ListVersions(ctx context.Context, id uint) ([]models.RecordVersion, error)
+	GetVersion(ctx context.Context, id uint, versionID uint) (*models.RecordVersion, error)
+	BulkUpdate(ctx context.Context, ids []uint, data map[string]interface{}) (BulkResult, error)
+	BulkDelete(ctx context.Context, ids []uint) (BulkResult, error)
+	BulkRestore(ctx context.Context, ids []uint) (BulkResult, error)
 }
 
 type BaseRepository[T any] struct {
@@ -25,33 +42,182 @@ func NewBaseRepository[T any](db *gorm.DB) *BaseRepository[T] {
 	return &BaseRepository[T]{db: db}
 }
 
-func (r *BaseRepository[T]) GetAll() ([]T, error) {
+func (r *BaseRepository[T]) GetAll(ctx context.Context) ([]T, error) {
 	var items []T
-	err := r.db.Find(&items).Error
+	err := reader(ctx, r.db).Find(&items).Error
 	return items, err
 }
 
-func (r *BaseRepository[T]) GetByID(id uint) (*T, error) {
+func (r *BaseRepository[T]) GetByID(ctx context.Context, id uint) (*T, error) {
 	var item T
-	err := r.db.First(&item, id).Error
+	err := reader(ctx, r.db).First(&item, id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, customerrors.ErrRepoRecordNotFound
 	}
 	return &item, err
 }
 
-func (r *BaseRepository[T]) GetCount() (int64, error) {
+func (r *BaseRepository[T]) GetCount(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.Model(new(T)).Count(&count).Error
+	err := reader(ctx, r.db).Model(new(T)).Count(&count).Error
 	return count, err
 }
 
+// List modelin ListSpec tanımına göre filtrelenmiş, sıralanmış ve sayfalanmış
+// kayıtları ve sayfa bilgisini döner. params.UseCursor ise OFFSET yerine keyset
+// sayfalama kullanılır; geçersiz veya sıralaması uymayan imleç ilk sayfaya döner.
+func (r *BaseRepository[T]) List(ctx context.Context, params queryparams.ListParams) ([]T, queryparams.PaginationMeta, error) {
+	return r.list(ctx, reader(ctx, r.db), params, params.HasFilters())
+}
+
+// ListTrashed soft delete ile silinmiş kayıtları List ile aynı kurallarla
+// listeler. Sayım tahmini tablonun tamamı için olduğundan burada kullanılmaz.
+func (r *BaseRepository[T]) ListTrashed(ctx context.Context, params queryparams.ListParams) ([]T, queryparams.PaginationMeta, error) {
+	return r.list(ctx, reader(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL"), params, true)
+}
+
+// Stream List ile aynı filtre ve sıralamadaki tüm kayıtları sayfalamadan,
+// bir veritabanı imleci üzerinden tek tek fn'e verir; kayıtlar belleğe
+// toplanmaz. fn hata dönerse okuma durur ve hata döner.
+func (r *BaseRepository[T]) Stream(ctx context.Context, params queryparams.ListParams, fn func(item *T) error) error {
+	return r.stream(reader(ctx, r.db), params, fn)
+}
+
+// StreamTrashed Stream'in silinmiş kayıtlar için olanıdır.
+func (r *BaseRepository[T]) StreamTrashed(ctx context.Context, params queryparams.ListParams, fn func(item *T) error) error {
+	return r.stream(reader(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL"), params, fn)
+}
+
+func (r *BaseRepository[T]) stream(base *gorm.DB, params queryparams.ListParams, fn func(item *T) error) error {
+	spec := listSpecOf[T]()
+	model := new(T)
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	keys := listSortKeys(stmt.Schema, spec, params)
+	query := applyListFilters(base.Model(model), spec, params)
+	query = applyListSort(applySearchRank(query, stmt.Schema.Table, spec, params), keys, false)
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item T
+		if err := r.db.ScanRows(rows, &item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *BaseRepository[T]) list(ctx context.Context, base *gorm.DB, params queryparams.ListParams, filtered bool) ([]T, queryparams.PaginationMeta, error) {
+	spec := listSpecOf[T]()
+	countMode := params.CountMode
+	if countMode == "" {
+		countMode = spec.CountMode
+	}
+	if countMode == "" {
+		countMode = queryparams.CountExact
+	}
+
+	meta := queryparams.PaginationMeta{PerPage: params.PerPage, CountMode: countMode}
+	model := new(T)
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, meta, err
+	}
+	keys := listSortKeys(stmt.Schema, spec, params)
+	query := applyListFilters(base.Model(model), spec, params).Session(&gorm.Session{})
+
+	var err error
+	meta.TotalItems, err = countList(query, stmt.Schema.Table, countMode, filtered)
+	if err != nil {
+		return nil, meta, err
+	}
+	if meta.TotalItems >= 0 {
+		meta.TotalPages = queryparams.CalculateTotalPages(meta.TotalItems, params.PerPage)
+	}
+
+	sortString := params.SortString()
+	page := query
+	backward := false
+	hasCursor := false
+	if params.UseCursor && params.Cursor != "" {
+		if cursor, err := queryparams.DecodeCursor(params.Cursor, sortString); err == nil {
+			if values, err := decodeCursorValues(keys, cursor.Values); err == nil {
+				condition, args := keysetCondition(query, keys, values, cursor.Backward)
+				page = page.Where(condition, args...)
+				backward = cursor.Backward
+				hasCursor = true
+			}
+		}
+	} else if !params.UseCursor {
+		meta.CurrentPage = params.Page
+		page = page.Offset(params.CalculateOffset())
+	}
+
+	var items []T
+	err = applyListSort(applySearchRank(page, stmt.Schema.Table, spec, params), keys, backward).Limit(params.PerPage + 1).Find(&items).Error
+	if err != nil {
+		return nil, meta, err
+	}
+
+	hasMore := len(items) > params.PerPage
+	if hasMore {
+		items = items[:params.PerPage]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	if !params.UseCursor {
+		meta.HasNext = hasMore
+		meta.HasPrev = params.Page > 1
+		if meta.HasNext {
+			meta.Next = params.URL("page", params.Page+1)
+		}
+		if meta.HasPrev {
+			meta.Prev = params.URL("page", params.Page-1)
+		}
+		return items, meta, nil
+	}
+
+	if backward {
+		meta.HasPrev, meta.HasNext = hasMore, true
+	} else {
+		meta.HasPrev, meta.HasNext = hasCursor, hasMore
+	}
+	if len(items) > 0 {
+		if meta.HasNext {
+			meta.NextCursor = encodeCursor(ctx, keys, reflect.ValueOf(&items[len(items)-1]).Elem(), sortString, false)
+			meta.Next = params.URL("cursor", meta.NextCursor)
+		}
+		if meta.HasPrev {
+			meta.PrevCursor = encodeCursor(ctx, keys, reflect.ValueOf(&items[0]).Elem(), sortString, true)
+			meta.Prev = params.URL("cursor", meta.PrevCursor)
+		}
+	}
+	return items, meta, nil
+}
+
 func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
-	return r.db.WithContext(ctx).Create(entity).Error
+	return primary(ctx, r.db).Create(entity).Error
 }
 
+// Update kaydı sürüm kontrolü yapmadan günceller. Modelde version kolonu
+// varsa yine de artırılır; böylece o sırada formu açık olan kullanıcılar
+// UpdateVersioned ile kaydederken değişikliği fark eder.
 func (r *BaseRepository[T]) Update(ctx context.Context, id uint, data map[string]interface{}) error {
-	result := r.db.WithContext(ctx).Model(new(T)).Where("id = ?", id).Updates(data)
+	result := primary(ctx, r.db).Model(new(T)).Where("id = ?", id).Updates(r.withVersionBump(data))
 	if result.Error != nil {
 		return result.Error
 	}
@@ -61,10 +227,59 @@ func (r *BaseRepository[T]) Update(ctx context.Context, id uint, data map[string
 	return nil
 }
 
+// UpdateVersioned kaydı yalnızca sürümü hâlâ version ise günceller ve sürümü
+// artırır. Kayıt arada değiştiyse ErrVersionConflict, yoksa
+// ErrRepoRecordNotFound döner.
+func (r *BaseRepository[T]) UpdateVersioned(ctx context.Context, id uint, version uint, data map[string]interface{}) error {
+	result := primary(ctx, r.db).Model(new(T)).
+		Where("id = ? AND version = ?", id, version).
+		Updates(r.withVersionBump(data))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.versionMismatch(ctx, id)
+	}
+	return nil
+}
+
+func (r *BaseRepository[T]) withVersionBump(data map[string]interface{}) map[string]interface{} {
+	if !r.hasField("version") {
+		return data
+	}
+	fields := make(map[string]interface{}, len(data)+1)
+	for key, value := range data {
+		fields[key] = value
+	}
+	fields["version"] = gorm.Expr("version + 1")
+	return fields
+}
+
+// versionMismatch koşullu güncelleme hiçbir satırı etkilemediğinde nedenini
+// ayırt eder: kayıt silinmiş mi, yoksa sürümü mü değişmiş.
+func (r *BaseRepository[T]) versionMismatch(ctx context.Context, id uint) error {
+	var count int64
+	if err := primary(ctx, r.db).Model(new(T)).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return customerrors.ErrRepoRecordNotFound
+	}
+	return customerrors.ErrVersionConflict
+}
+
+func (r *BaseRepository[T]) hasField(name string) bool {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return false
+	}
+	return stmt.Schema.LookUpField(name) != nil
+}
+
 func (r *BaseRepository[T]) Delete(ctx context.Context, id uint) error {
 	var entity T
 
-	findTx := r.db.WithContext(ctx).First(&entity, id)
+	findTx := primary(ctx, r.db).First(&entity, id)
 	if findTx.Error != nil {
 		if errors.Is(findTx.Error, gorm.ErrRecordNotFound) {
 			return customerrors.ErrRepoRecordNotFound
@@ -78,13 +293,13 @@ func (r *BaseRepository[T]) Delete(ctx context.Context, id uint) error {
 	}
 
 	if r.db.Migrator().HasColumn(&entity, "deleted_by") {
-		updateTx := r.db.WithContext(ctx).Model(&entity).Update("deleted_by", userID)
+		updateTx := primary(ctx, r.db).Model(&entity).Update("deleted_by", userID)
 		if updateTx.Error != nil {
 			return updateTx.Error
 		}
 	}
 
-	deleteTx := r.db.WithContext(ctx).Delete(&entity)
+	deleteTx := primary(ctx, r.db).Delete(&entity)
 	if deleteTx.Error != nil {
 		return deleteTx.Error
 	}
@@ -95,4 +310,43 @@ func (r *BaseRepository[T]) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
+// Restore silinmiş bir kaydı geri getirir. Kayıt çöp kutusunda değilse
+// ErrRepoRecordNotFound, geri gelmesi tekil bir alanı (ör. aynı hesap adıyla
+// açılmış yeni bir kayıt) çiğneyecekse ErrRestoreConflict döner.
+func (r *BaseRepository[T]) Restore(ctx context.Context, id uint) error {
+	result := primary(ctx, r.db).Unscoped().Model(new(T)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil})
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return customerrors.ErrRestoreConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customerrors.ErrRepoRecordNotFound
+	}
+	return nil
+}
+
+// ForceDelete çöp kutusundaki bir kaydı kalıcı olarak siler. Aktif kayıtlar
+// bu yolla silinemez; önce Delete ile çöp kutusuna taşınmalıdır.
+func (r *BaseRepository[T]) ForceDelete(ctx context.Context, id uint) error {
+	result := primary(ctx, r.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(new(T))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customerrors.ErrRepoRecordNotFound
+	}
+	return nil
+}
+
+// PurgeTrashed before'dan önce silinmiş kayıtları kalıcı olarak siler ve
+// silinen kayıt sayısını döner.
+func (r *BaseRepository[T]) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	result := primary(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(new(T))
+	return result.RowsAffected, result.Error
+}
+
 var _ IBaseRepository[any] = (*BaseRepository[any])(nil)
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// BulkResult toplu bir işlemin satır bazındaki sonucudur. Failed'daki
+// satırlar geri alınmıştır; Succeeded'daki satırlar kalıcıdır.
+type BulkResult struct {
+	Succeeded []uint
+	Failed    map[uint]error
+}
+
+// BulkUpdate data'yı her satıra Update ile uygular (sürüm artar, updated_by
+// context'teki kullanıcıdan gelir).
+func (r *BaseRepository[T]) BulkUpdate(ctx context.Context, ids []uint, data map[string]interface{}) (BulkResult, error) {
+	return r.bulk(ctx, ids, func(ctx context.Context, id uint) error {
+		return r.Update(ctx, id, data)
+	})
+}
+
+// BulkDelete satırları Delete ile çöp kutusuna taşır.
+func (r *BaseRepository[T]) BulkDelete(ctx context.Context, ids []uint) (BulkResult, error) {
+	return r.bulk(ctx, ids, r.Delete)
+}
+
+// BulkRestore satırları Restore ile çöp kutusundan geri getirir.
+func (r *BaseRepository[T]) BulkRestore(ctx context.Context, ids []uint) (BulkResult, error) {
+	return r.bulk(ctx, ids, r.Restore)
+}
+
+// bulk fn'i tüm satırlar için tek bir transaction içinde, her satırı kendi
+// savepoint'inde çalıştırır. Bir satırın hatası yalnızca o satırı geri alır
+// ve Failed'a yazılır; diğer satırlar işlenmeye devam eder. Transaction'ın
+// kendisi başarısız olursa hiçbir satır kalıcı olmaz ve hata döner.
+func (r *BaseRepository[T]) bulk(ctx context.Context, ids []uint, fn func(ctx context.Context, id uint) error) (BulkResult, error) {
+	result := BulkResult{Failed: make(map[uint]error)}
+	seen := make(map[uint]bool, len(ids))
+
+	err := primary(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			err := tx.Transaction(func(row *gorm.DB) error {
+				return fn(context.WithValue(ctx, txKey{}, row), id)
+			})
+			if err != nil {
+				result.Failed[id] = err
+				continue
+			}
+			result.Succeeded = append(result.Succeeded, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return BulkResult{Failed: result.Failed}, err
+	}
+	return result, nil
+}
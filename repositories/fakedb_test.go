@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeQuery fakeDB'ye gelen bir sorgu ve parametreleridir.
+type fakeQuery struct {
+	SQL  string
+	Args []interface{}
+}
+
+// fakeRows bir sorguya dönülecek sonuçtur.
+type fakeRows struct {
+	Columns []string
+	Rows    [][]driver.Value
+}
+
+// fakeDB gerçek veritabanı olmadan repository sorgularını test etmek için
+// kullanılır: gelen sorguları kaydeder ve respond'un döndüğü satırları verir.
+// BEGIN, COMMIT ve ROLLBACK de sorgu olarak kaydedilir. fail nil değilse
+// sorgu ve COMMIT'lerde çağrılır; döndüğü hata sürücü hatası olarak döner.
+type fakeDB struct {
+	mu      sync.Mutex
+	queries []fakeQuery
+	respond func(query string) fakeRows
+	fail    func(query string) error
+}
+
+func newFakeGorm(t *testing.T, respond func(query string) fakeRows) (*gorm.DB, *fakeDB) {
+	t.Helper()
+	fake := &fakeDB{respond: respond}
+	sqlDB := sql.OpenDB(fake)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, fake
+}
+
+// Queries prefix ile başlayan kayıtlı sorguları döner.
+func (f *fakeDB) Queries(prefix string) []fakeQuery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var queries []fakeQuery
+	for _, q := range f.queries {
+		if strings.HasPrefix(q.SQL, prefix) {
+			queries = append(queries, q)
+		}
+	}
+	return queries
+}
+
+// SQL kayıtlı sorguların metinlerini sırayla döner.
+func (f *fakeDB) SQL() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statements := make([]string, len(f.queries))
+	for i, q := range f.queries {
+		statements[i] = q.SQL
+	}
+	return statements
+}
+
+func (f *fakeDB) record(query string, args []driver.NamedValue) error {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	f.mu.Lock()
+	f.queries = append(f.queries, fakeQuery{SQL: query, Args: values})
+	fail := f.fail
+	f.mu.Unlock()
+	if fail != nil {
+		return fail(query)
+	}
+	return nil
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return fakeDriver{f} }
+
+type fakeDriver struct{ db *fakeDB }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return fakeConn(d), nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) {
+	if err := c.db.record("BEGIN", nil); err != nil {
+		return nil, err
+	}
+	return fakeTx(c), nil
+}
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.db.record(query, args); err != nil {
+		return nil, err
+	}
+	var result fakeRows
+	if c.db.respond != nil {
+		result = c.db.respond(query)
+	}
+	return &fakeCursor{result: result}, nil
+}
+
+func (c fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.db.record(query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (t fakeTx) Commit() error { return t.db.record("COMMIT", nil) }
+
+func (t fakeTx) Rollback() error {
+	_ = t.db.record("ROLLBACK", nil)
+	return nil
+}
+
+type fakeCursor struct {
+	result fakeRows
+	next   int
+}
+
+func (r *fakeCursor) Columns() []string { return r.result.Columns }
+func (r *fakeCursor) Close() error      { return nil }
+
+func (r *fakeCursor) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.Rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.Rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/models"
+	"zatrano/pkg/customerrors"
+
+	"gorm.io/gorm"
+)
+
+func historyTableOf[T any]() string {
+	if historied, ok := any(new(T)).(models.Historied); ok {
+		return historied.HistoryTable()
+	}
+	return ""
+}
+
+// ListVersions kaydın sürüm geçmişini yeniden eskiye döner. Model geçmiş
+// tutmuyorsa liste boştur.
+func (r *BaseRepository[T]) ListVersions(ctx context.Context, id uint) ([]models.RecordVersion, error) {
+	table := historyTableOf[T]()
+	if table == "" {
+		return nil, nil
+	}
+	var versions []models.RecordVersion
+	err := reader(ctx, r.db).Table(table).
+		Where("record_id = ?", id).
+		Order("version DESC").Order("id DESC").
+		Find(&versions).Error
+	return versions, err
+}
+
+func (r *BaseRepository[T]) GetVersion(ctx context.Context, id uint, versionID uint) (*models.RecordVersion, error) {
+	table := historyTableOf[T]()
+	if table == "" {
+		return nil, customerrors.ErrRepoRecordNotFound
+	}
+	var version models.RecordVersion
+	err := reader(ctx, r.db).Table(table).Where("record_id = ?", id).First(&version, versionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, customerrors.ErrRepoRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/turkishsearch"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+func listSpecOf[T any]() queryparams.ListSpec {
+	if provider, ok := any(new(T)).(queryparams.ListSpecProvider); ok {
+		return provider.ListSpec()
+	}
+	return queryparams.ListSpec{}
+}
+
+// applyListFilters arama ve filtreleri sorguya ekler. Kolon adları her zaman
+// spec'ten alınır; kullanıcıdan gelen değerler yalnızca parametre olarak geçer.
+func applyListFilters(db *gorm.DB, spec queryparams.ListSpec, params queryparams.ListParams) *gorm.DB {
+	if condition, args := turkishsearch.Condition(spec.SearchColumns, params.Search, spec.SearchFuzzy); condition != "" {
+		db = db.Where(condition, args...)
+	}
+
+	fields := make([]string, 0, len(params.Filters))
+	for field := range params.Filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		filter := params.Filters[field]
+		filterSpec, ok := spec.Filters[field]
+		if !ok {
+			continue
+		}
+		column := clause.Column{Name: filterSpec.Column}
+
+		switch filterSpec.Type {
+		case queryparams.FilterEq:
+			db = db.Where(clause.Eq{Column: column, Value: filter.Values[0]})
+		case queryparams.FilterIn:
+			values := make([]interface{}, len(filter.Values))
+			for i, v := range filter.Values {
+				values[i] = v
+			}
+			db = db.Where(clause.IN{Column: column, Values: values})
+		case queryparams.FilterBool:
+			value, _ := strconv.ParseBool(filter.Values[0])
+			db = db.Where(clause.Eq{Column: column, Value: value})
+		case queryparams.FilterRange:
+			if filter.From != "" {
+				from, _ := strconv.ParseFloat(filter.From, 64)
+				db = db.Where(clause.Gte{Column: column, Value: from})
+			}
+			if filter.To != "" {
+				to, _ := strconv.ParseFloat(filter.To, 64)
+				db = db.Where(clause.Lte{Column: column, Value: to})
+			}
+		case queryparams.FilterDateRange:
+			if filter.From != "" {
+				from, _ := time.ParseInLocation(queryparams.DateLayout, filter.From, params.DateLocation())
+				db = db.Where(clause.Gte{Column: column, Value: from})
+			}
+			if filter.To != "" {
+				to, _ := time.ParseInLocation(queryparams.DateLayout, filter.To, params.DateLocation())
+				db = db.Where(clause.Lt{Column: column, Value: to.AddDate(0, 0, 1)})
+			}
+		case queryparams.FilterLike:
+			condition, args := turkishsearch.SQLFilter(filterSpec.Column, filter.Values[0])
+			db = db.Where(condition, args...)
+		}
+	}
+
+	return db
+}
+
+type sortKey struct {
+	column string
+	desc   bool
+	field  *schema.Field
+}
+
+// listSortKeys sıralama kolonlarını döner; sayfalar arası kararlı sıra ve
+// keyset imleci için sona her zaman id eklenir.
+func listSortKeys(modelSchema *schema.Schema, spec queryparams.ListSpec, params queryparams.ListParams) []sortKey {
+	var keys []sortKey
+	hasID := false
+	for _, sortField := range params.Sort {
+		column, ok := spec.Sorts[sortField.Field]
+		if !ok {
+			continue
+		}
+		if column == "id" {
+			hasID = true
+		}
+		keys = append(keys, sortKey{column: column, desc: sortField.Desc, field: modelSchema.LookUpField(column)})
+	}
+	if !hasID {
+		keys = append(keys, sortKey{column: "id", field: modelSchema.LookUpField("id")})
+	}
+	return keys
+}
+
+// applyListSort sıralamayı ekler; backward true ise yön tersine çevrilir
+// (önceki sayfaya giderken kullanılır, sonuç sonradan tekrar ters çevrilir).
+func applyListSort(db *gorm.DB, keys []sortKey, backward bool) *gorm.DB {
+	for _, key := range keys {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: key.column}, Desc: key.desc != backward})
+	}
+	return db
+}
+
+// applySearchRank arama sonuçlarını, diğer sıralamalardan önce, aramaya en
+// benzer kayıt başta olacak şekilde sıralar (bkz. ListParams.RankBySearch).
+// GORM'un ORDER BY birleştirmesi parametreli ifadeleri korumadığından
+// benzerlik seçilen bir kolon olarak hesaplanır ve adıyla sıralanır.
+func applySearchRank(db *gorm.DB, table string, spec queryparams.ListSpec, params queryparams.ListParams) *gorm.DB {
+	if !params.RankBySearch(spec) {
+		return db
+	}
+	expression, args := turkishsearch.RankExpression(spec.SearchColumns, params.Search)
+	if expression == "" {
+		return db
+	}
+	return db.Select(db.Statement.Quote(table)+".*, "+expression+" AS search_rank", args...).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "search_rank"}, Desc: true})
+}
+
+// keysetCondition imleçteki satırdan sonra (backward ise önce) gelen satırları
+// seçen koşulu üretir: (a > ?) OR (a = ? AND b > ?) OR ...
+func keysetCondition(db *gorm.DB, keys []sortKey, values []interface{}, backward bool) (string, []interface{}) {
+	var (
+		branches []string
+		args     []interface{}
+	)
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, db.Statement.Quote(clause.Column{Name: keys[j].column})+" = ?")
+			args = append(args, values[j])
+		}
+		operator := ">"
+		if key.desc != backward {
+			operator = "<"
+		}
+		parts = append(parts, db.Statement.Quote(clause.Column{Name: key.column})+" "+operator+" ?")
+		args = append(args, values[i])
+		branches = append(branches, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(branches, " OR ") + ")", args
+}
+
+// encodeCursor satırın sıralama kolonlarındaki değerlerinden imleç üretir.
+func encodeCursor(ctx context.Context, keys []sortKey, row reflect.Value, sort string, backward bool) string {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		if key.field == nil {
+			return ""
+		}
+		value, _ := key.field.ValueOf(ctx, row)
+		if t, ok := value.(time.Time); ok {
+			values[i] = t.UTC().Format(time.RFC3339Nano)
+		} else {
+			values[i] = fmt.Sprint(value)
+		}
+	}
+	return queryparams.Cursor{Sort: sort, Values: values, Backward: backward}.Encode()
+}
+
+// decodeCursorValues imleçteki metin değerleri kolon tiplerine geri çevirir.
+func decodeCursorValues(keys []sortKey, raw []string) ([]interface{}, error) {
+	if len(raw) != len(keys) {
+		return nil, queryparams.ErrInvalidCursor
+	}
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if key.field == nil {
+			return nil, queryparams.ErrInvalidCursor
+		}
+		var err error
+		switch key.field.DataType {
+		case schema.Time:
+			values[i], err = time.Parse(time.RFC3339Nano, raw[i])
+		case schema.Bool:
+			values[i], err = strconv.ParseBool(raw[i])
+		case schema.Int:
+			values[i], err = strconv.ParseInt(raw[i], 10, 64)
+		case schema.Uint:
+			values[i], err = strconv.ParseUint(raw[i], 10, 64)
+		case schema.Float:
+			values[i], err = strconv.ParseFloat(raw[i], 64)
+		default:
+			values[i] = raw[i]
+		}
+		if err != nil {
+			return nil, queryparams.ErrInvalidCursor
+		}
+	}
+	return values, nil
+}
+
+// countList toplam kayıt sayısını CountMode'a göre hesaplar; bilinmiyorsa -1
+// döner. Tahmin yalnızca filtresiz listelerde pg_class.reltuples ile yapılır,
+// filtreli listelerde sayım atlanır.
+func countList(query *gorm.DB, table string, mode queryparams.CountMode, filtered bool) (int64, error) {
+	switch mode {
+	case queryparams.CountNone:
+		return -1, nil
+	case queryparams.CountEstimate:
+		if filtered {
+			return -1, nil
+		}
+		var estimate float64
+		err := query.Session(&gorm.Session{NewDB: true}).
+			Raw("SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)", table).
+			Scan(&estimate).Error
+		if err != nil {
+			return 0, err
+		}
+		if estimate >= 0 {
+			return int64(estimate), nil
+		}
+	}
+
+	var total int64
+	err := query.Count(&total).Error
+	return total, err
+}
@@ -0,0 +1,316 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func userSchema(t *testing.T, db *gorm.DB) *schema.Schema {
+	t.Helper()
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&models.User{}); err != nil {
+		t.Fatal(err)
+	}
+	return stmt.Schema
+}
+
+func userListParams(t *testing.T, queries map[string]string) queryparams.ListParams {
+	t.Helper()
+	params, err := queryparams.Parse(queries, models.User{}.ListSpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return params
+}
+
+func TestListSortKeys(t *testing.T) {
+	db, _ := newFakeGorm(t, nil)
+	userSchema := userSchema(t, db)
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"-id"}},
+		{"name", []string{"name", "id"}},
+		{"-created_at,name", []string{"-created_at", "name", "id"}},
+		{"-id,name", []string{"-id", "name"}},
+	}
+	for _, tt := range tests {
+		keys := listSortKeys(userSchema, models.User{}.ListSpec(), userListParams(t, map[string]string{"sort": tt.sort}))
+		got := make([]string, len(keys))
+		for i, key := range keys {
+			if key.field == nil {
+				t.Errorf("sort=%q: %s kolonunun alanı bulunamadı", tt.sort, key.column)
+			}
+			got[i] = key.column
+			if key.desc {
+				got[i] = "-" + key.column
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sort=%q: anahtarlar %v, want %v", tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	db, _ := newFakeGorm(t, nil)
+	keys := []sortKey{{column: "name"}, {column: "created_at", desc: true}, {column: "id"}}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	values := []interface{}{"Ayşe", created, uint64(7)}
+
+	tests := []struct {
+		name     string
+		backward bool
+		want     string
+	}{
+		{
+			name: "ileri",
+			want: `(("name" > ?) OR ("name" = ? AND "created_at" < ?) OR ("name" = ? AND "created_at" = ? AND "id" > ?))`,
+		},
+		{
+			name:     "geri",
+			backward: true,
+			want:     `(("name" < ?) OR ("name" = ? AND "created_at" > ?) OR ("name" = ? AND "created_at" = ? AND "id" < ?))`,
+		},
+	}
+	wantArgs := []interface{}{"Ayşe", "Ayşe", created, "Ayşe", created, uint64(7)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, args := keysetCondition(db, keys, values, tt.backward)
+			if condition != tt.want {
+				t.Errorf("koşul:\n got  %s\n want %s", condition, tt.want)
+			}
+			if !reflect.DeepEqual(args, wantArgs) {
+				t.Errorf("args = %v, want %v", args, wantArgs)
+			}
+		})
+	}
+
+	t.Run("tek kolon", func(t *testing.T) {
+		condition, args := keysetCondition(db, []sortKey{{column: "id", desc: true}}, []interface{}{uint64(3)}, false)
+		if condition != `(("id" < ?))` || !reflect.DeepEqual(args, []interface{}{uint64(3)}) {
+			t.Errorf("koşul = %s %v", condition, args)
+		}
+	})
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	db, _ := newFakeGorm(t, nil)
+	params := userListParams(t, map[string]string{"sort": "-created_at,status,type,name"})
+	keys := listSortKeys(userSchema(t, db), models.User{}.ListSpec(), params)
+
+	user := models.User{Name: "İlkay, \"Ş\"", Status: false, Type: models.Dashboard}
+	user.ID = 42
+	user.CreatedAt = time.Date(2024, 2, 29, 23, 59, 59, 123456789, time.FixedZone("TRT", 3*3600))
+
+	for _, backward := range []bool{false, true} {
+		encoded := encodeCursor(context.Background(), keys, reflect.ValueOf(&user).Elem(), params.SortString(), backward)
+		cursor, err := queryparams.DecodeCursor(encoded, params.SortString())
+		if err != nil {
+			t.Fatalf("DecodeCursor: %v", err)
+		}
+		if cursor.Backward != backward {
+			t.Errorf("Backward = %v, want %v", cursor.Backward, backward)
+		}
+		values, err := decodeCursorValues(keys, cursor.Values)
+		if err != nil {
+			t.Fatalf("decodeCursorValues: %v", err)
+		}
+		if got, ok := values[0].(time.Time); !ok || !got.Equal(user.CreatedAt) {
+			t.Errorf("created_at = %v, want %v", values[0], user.CreatedAt)
+		}
+		want := []interface{}{false, "dashboard", user.Name, uint64(42)}
+		if !reflect.DeepEqual(values[1:], want) {
+			t.Errorf("değerler = %#v, want %#v", values[1:], want)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	valid := queryparams.Cursor{Sort: "name", Values: []string{"a", "1"}}.Encode()
+	tests := []struct {
+		name, encoded, sort string
+	}{
+		{"base64 değil", "!!!", "name"},
+		{"JSON değil", "bm90LWpzb24", "name"},
+		{"başka sıralama", valid, "-name"},
+		{"değersiz", queryparams.Cursor{Sort: "name"}.Encode(), "name"},
+		{"kesilmiş", valid[:len(valid)-3], "name"},
+		{"değiştirilmiş", strings.Replace(valid, "bmFtZ", "bmFtX", 1), "name"},
+	}
+	for _, tt := range tests {
+		if _, err := queryparams.DecodeCursor(tt.encoded, tt.sort); !errors.Is(err, queryparams.ErrInvalidCursor) {
+			t.Errorf("%s: hata = %v, ErrInvalidCursor bekleniyordu", tt.name, err)
+		}
+	}
+	if _, err := queryparams.DecodeCursor(valid, "name"); err != nil {
+		t.Errorf("geçerli imleç reddedildi: %v", err)
+	}
+}
+
+func TestDecodeCursorValuesInvalid(t *testing.T) {
+	db, _ := newFakeGorm(t, nil)
+	userSchema := userSchema(t, db)
+	keys := listSortKeys(userSchema, models.User{}.ListSpec(), userListParams(t, map[string]string{"sort": "-created_at,status"}))
+
+	tests := []struct {
+		name string
+		raw  []string
+	}{
+		{"eksik değer", []string{"2024-01-01T00:00:00Z", "true"}},
+		{"fazla değer", []string{"2024-01-01T00:00:00Z", "true", "1", "2"}},
+		{"geçersiz zaman", []string{"2024-01-01", "true", "1"}},
+		{"geçersiz bool", []string{"2024-01-01T00:00:00Z", "belki", "1"}},
+		{"negatif id", []string{"2024-01-01T00:00:00Z", "true", "-1"}},
+		{"boş id", []string{"2024-01-01T00:00:00Z", "true", "<nil>"}},
+	}
+	for _, tt := range tests {
+		if _, err := decodeCursorValues(keys, tt.raw); !errors.Is(err, queryparams.ErrInvalidCursor) {
+			t.Errorf("%s: hata = %v, ErrInvalidCursor bekleniyordu", tt.name, err)
+		}
+	}
+
+	unknown := []sortKey{{column: "missing", field: userSchema.LookUpField("missing")}}
+	if _, err := decodeCursorValues(unknown, []string{"x"}); !errors.Is(err, queryparams.ErrInvalidCursor) {
+		t.Errorf("alanı olmayan kolon: hata = %v", err)
+	}
+}
+
+func TestListKeysetPages(t *testing.T) {
+	userRows := func(rows ...[]driver.Value) fakeRows {
+		return fakeRows{Columns: []string{"id", "name"}, Rows: rows}
+	}
+	tests := []struct {
+		name      string
+		cursor    queryparams.Cursor
+		rows      fakeRows
+		wantWhere string
+		wantOrder string
+		wantArgs  string
+		wantIDs   []uint
+		wantNext  []string
+		wantPrev  []string
+	}{
+		{
+			name:      "sonraki sayfa",
+			cursor:    queryparams.Cursor{Sort: "name", Values: []string{"b", "2"}},
+			rows:      userRows([]driver.Value{int64(3), "c"}, []driver.Value{int64(4), "d"}, []driver.Value{int64(5), "e"}),
+			wantWhere: `(("name" > $1) OR ("name" = $2 AND "id" > $3))`,
+			wantOrder: `ORDER BY "name","id" LIMIT $4`,
+			wantArgs:  "[b b 2 3]",
+			wantIDs:   []uint{3, 4},
+			wantNext:  []string{"d", "4"},
+			wantPrev:  []string{"c", "3"},
+		},
+		{
+			name:      "önceki sayfa ters sırayla okunup çevrilir",
+			cursor:    queryparams.Cursor{Sort: "name", Values: []string{"e", "5"}, Backward: true},
+			rows:      userRows([]driver.Value{int64(4), "d"}, []driver.Value{int64(3), "c"}, []driver.Value{int64(2), "b"}),
+			wantWhere: `(("name" < $1) OR ("name" = $2 AND "id" < $3))`,
+			wantOrder: `ORDER BY "name" DESC,"id" DESC LIMIT $4`,
+			wantArgs:  "[e e 5 3]",
+			wantIDs:   []uint{3, 4},
+			wantNext:  []string{"d", "4"},
+			wantPrev:  []string{"c", "3"},
+		},
+		{
+			name:      "ilk sayfaya dönülen önceki sayfa",
+			cursor:    queryparams.Cursor{Sort: "name", Values: []string{"c", "3"}, Backward: true},
+			rows:      userRows([]driver.Value{int64(2), "b"}, []driver.Value{int64(1), "a"}),
+			wantWhere: `(("name" < $1) OR ("name" = $2 AND "id" < $3))`,
+			wantOrder: `ORDER BY "name" DESC,"id" DESC LIMIT $4`,
+			wantArgs:  "[c c 3 3]",
+			wantIDs:   []uint{1, 2},
+			wantNext:  []string{"b", "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := newFakeGorm(t, func(query string) fakeRows {
+				if strings.HasPrefix(query, "SELECT count(*)") {
+					return fakeRows{Columns: []string{"count"}, Rows: [][]driver.Value{{int64(5)}}}
+				}
+				return tt.rows
+			})
+			repo := NewBaseRepository[models.User](db)
+			params := userListParams(t, map[string]string{"sort": "name", "perPage": "2", "cursor": tt.cursor.Encode()})
+
+			items, meta, err := repo.List(context.Background(), params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			selects := fake.Queries(`SELECT * FROM "users"`)
+			if len(selects) != 1 {
+				t.Fatalf("%d liste sorgusu çalıştı", len(selects))
+			}
+			query := selects[0]
+			if !strings.Contains(query.SQL, "WHERE ("+tt.wantWhere+")") || !strings.HasSuffix(query.SQL, tt.wantOrder) {
+				t.Errorf("sorgu:\n %s\nkoşul %s ve %s bekleniyordu", query.SQL, tt.wantWhere, tt.wantOrder)
+			}
+			if got := fmt.Sprint(query.Args); got != tt.wantArgs {
+				t.Errorf("args = %s, want %s", got, tt.wantArgs)
+			}
+
+			ids := make([]uint, len(items))
+			for i, item := range items {
+				ids[i] = item.ID
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("kayıtlar = %v, want %v", ids, tt.wantIDs)
+			}
+			checkCursor(t, "next", meta.NextCursor, tt.wantNext, false)
+			checkCursor(t, "prev", meta.PrevCursor, tt.wantPrev, true)
+			if meta.HasNext != (tt.wantNext != nil) || meta.HasPrev != (tt.wantPrev != nil) {
+				t.Errorf("HasNext/HasPrev = %v/%v", meta.HasNext, meta.HasPrev)
+			}
+		})
+	}
+}
+
+func TestListInvalidCursorStartsOver(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	repo := NewBaseRepository[models.User](db)
+	params := userListParams(t, map[string]string{
+		"sort":   "name",
+		"cursor": queryparams.Cursor{Sort: "name", Values: []string{"b", "iki"}}.Encode(),
+	})
+
+	if _, meta, err := repo.List(context.Background(), params); err != nil || meta.HasPrev {
+		t.Fatalf("List: meta %+v, hata %v", meta, err)
+	}
+	selects := fake.Queries(`SELECT * FROM "users"`)
+	if len(selects) != 1 || strings.Contains(selects[0].SQL, `"name" >`) {
+		t.Errorf("geçersiz imleç koşula eklendi: %v", selects)
+	}
+}
+
+func checkCursor(t *testing.T, name, encoded string, want []string, backward bool) {
+	t.Helper()
+	if want == nil {
+		if encoded != "" {
+			t.Errorf("%s imleci boş bekleniyordu", name)
+		}
+		return
+	}
+	cursor, err := queryparams.DecodeCursor(encoded, "name")
+	if err != nil {
+		t.Fatalf("%s imleci çözülemedi: %v", name, err)
+	}
+	if !reflect.DeepEqual(cursor.Values, want) || cursor.Backward != backward {
+		t.Errorf("%s imleci = %+v, want %v (backward %v)", name, cursor, want, backward)
+	}
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type readYourWritesKey struct{}
+
+// ReadYourWrites, dönen context ile yapılan okumaların replika yerine birincil
+// veritabanından yapılmasını sağlar. Bir yazma işleminin hemen ardından aynı
+// kaydı okuyan akışlarda replika gecikmesinden etkilenmemek için kullanılır.
+func ReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+func readsFromPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return primary
+}
+
+// reader okuma sorguları için bağlantıyı döner. Context'te transaction varsa
+// onu kullanır; yoksa ve replika tanımlıysa dbresolver sorguyu replikaya yönlendirir.
+func reader(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	tx := db.WithContext(ctx)
+	if readsFromPrimary(ctx) {
+		tx = tx.Clauses(dbresolver.Write)
+	}
+	return tx
+}
+
+// primary yazmalar ve tutarlı okumalar için bağlantıyı döner: context'teki
+// transaction ya da birincil veritabanı.
+func primary(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx).Clauses(dbresolver.Write)
+}
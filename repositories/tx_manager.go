@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const txRetryBaseDelay = 20 * time.Millisecond
+
+type txKey struct{}
+
+type ITxManager interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...*sql.TxOptions) error
+}
+
+// TxManager birden fazla repository çağrısını tek bir transaction içinde
+// çalıştırır. Transaction context'e konur; ctx alan tüm repository metotları
+// context'te transaction varsa onu kullanır.
+type TxManager struct {
+	db         *gorm.DB
+	maxRetries int
+}
+
+func NewTxManager(db *gorm.DB, maxRetries int) ITxManager {
+	return &TxManager{db: db, maxRetries: maxRetries}
+}
+
+// RunInTx fn'i bir transaction içinde çalıştırır; fn hata dönerse geri alınır.
+// ctx zaten bir transaction taşıyorsa iç içe çağrı savepoint ile yapılır ve
+// yalnızca o savepoint'e geri dönülür. En dıştaki transaction serileştirme
+// hatası (40001) veya deadlock (40P01) ile biterse baştan yeniden denenir;
+// bu nedenle fn yan etkisiz ve tekrar çalıştırılabilir olmalıdır.
+func (m *TxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...*sql.TxOptions) error {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.WithContext(ctx).Transaction(func(nested *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, nested))
+		})
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		}, opts...)
+		if err == nil || !isRetryableTxError(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		delay := txRetryBaseDelay << attempt
+		delay += time.Duration(rand.Int63n(int64(delay)))
+		logs.Log.Warn("Transaction çakışma nedeniyle yeniden deneniyor",
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+func txFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+var _ ITxManager = (*TxManager)(nil)
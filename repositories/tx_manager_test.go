@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"zatrano/pkg/logs"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func init() {
+	logs.Log = zap.NewNop()
+	logs.SLog = logs.Log.Sugar()
+}
+
+func execIn(ctx context.Context, db *gorm.DB, sql string) error {
+	return primary(ctx, db).Exec(sql).Error
+}
+
+func TestRunInTxCommitAndRollback(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	manager := NewTxManager(db, 0)
+	ctx := context.Background()
+
+	if err := manager.RunInTx(ctx, func(ctx context.Context) error {
+		return execIn(ctx, db, "INSERT a")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	errFn := errors.New("iş kuralı hatası")
+	if err := manager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := execIn(ctx, db, "INSERT b"); err != nil {
+			return err
+		}
+		return errFn
+	}); !errors.Is(err, errFn) {
+		t.Fatalf("hata = %v, want %v", err, errFn)
+	}
+
+	want := []string{"BEGIN", "INSERT a", "COMMIT", "BEGIN", "INSERT b", "ROLLBACK"}
+	if got := fake.SQL(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("sorgular = %q, want %q", got, want)
+	}
+}
+
+func TestRunInTxNestedSavepoint(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	manager := NewTxManager(db, 3)
+	errInner := errors.New("iç işlem başarısız")
+
+	var innerErr error
+	err := manager.RunInTx(context.Background(), func(ctx context.Context) error {
+		if err := execIn(ctx, db, "INSERT outer"); err != nil {
+			return err
+		}
+		innerErr = manager.RunInTx(ctx, func(ctx context.Context) error {
+			if err := execIn(ctx, db, "INSERT inner"); err != nil {
+				return err
+			}
+			return errInner
+		})
+		if err := manager.RunInTx(ctx, func(ctx context.Context) error {
+			return execIn(ctx, db, "INSERT second")
+		}); err != nil {
+			return err
+		}
+		return execIn(ctx, db, "INSERT after")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(innerErr, errInner) {
+		t.Errorf("iç hata = %v, want %v", innerErr, errInner)
+	}
+
+	// İç hata yalnızca kendi savepoint'ini geri alır; dış transaction ve
+	// sonraki iç çağrı devam eder ve commit edilir.
+	got := fake.SQL()
+	if len(got) != 9 || !strings.HasPrefix(got[2], "SAVEPOINT ") {
+		t.Fatalf("sorgular = %q", got)
+	}
+	savepoint := strings.TrimPrefix(got[2], "SAVEPOINT ")
+	want := []string{
+		"BEGIN",
+		"INSERT outer",
+		"SAVEPOINT " + savepoint,
+		"INSERT inner",
+		"ROLLBACK TO SAVEPOINT " + savepoint,
+		got[5],
+		"INSERT second",
+		"INSERT after",
+		"COMMIT",
+	}
+	if !strings.HasPrefix(got[5], "SAVEPOINT ") || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("sorgular = %q, want %q", got, want)
+	}
+}
+
+func TestRunInTxNestedDoesNotRetry(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	manager := NewTxManager(db, 3)
+	serialization := &pgconn.PgError{Code: pgSerializationFailure}
+
+	inner := 0
+	err := manager.RunInTx(context.Background(), func(ctx context.Context) error {
+		return manager.RunInTx(ctx, func(ctx context.Context) error {
+			inner++
+			if inner == 1 {
+				return serialization
+			}
+			return nil
+		})
+	})
+	// İç çağrı yeniden denenmez; hata dış transaction'a taşınır ve en
+	// dıştaki tur baştan çalışır.
+	if err != nil || inner != 2 {
+		t.Errorf("hata = %v, iç çalıştırma = %d; nil, 2 bekleniyordu", err, inner)
+	}
+	if begins := fake.Queries("BEGIN"); len(begins) != 2 {
+		t.Errorf("%d transaction açıldı, 2 bekleniyordu", len(begins))
+	}
+}
+
+func TestRunInTxRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		failures     int
+		maxRetries   int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"serileştirme hatası tekrar denenir", &pgconn.PgError{Code: pgSerializationFailure}, 2, 3, 3, false},
+		{"deadlock tekrar denenir", fmt.Errorf("sarılmış: %w", &pgconn.PgError{Code: pgDeadlockDetected}), 1, 3, 2, false},
+		{"deneme sınırında durur", &pgconn.PgError{Code: pgSerializationFailure}, 10, 2, 3, true},
+		{"yeniden denenmez hata", &pgconn.PgError{Code: pgUniqueViolation}, 10, 3, 1, true},
+		{"sınır sıfırsa tek deneme", &pgconn.PgError{Code: pgDeadlockDetected}, 10, 0, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := newFakeGorm(t, nil)
+			failures := tt.failures
+			fake.fail = func(query string) error {
+				if query == "UPDATE x" && failures > 0 {
+					failures--
+					return tt.err
+				}
+				return nil
+			}
+			manager := NewTxManager(db, tt.maxRetries)
+
+			attempts := 0
+			err := manager.RunInTx(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return execIn(ctx, db, "UPDATE x")
+			})
+			if attempts != tt.wantAttempts {
+				t.Errorf("deneme = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if (err != nil) != tt.wantErr || (err != nil && pgErrorCode(err) != pgErrorCode(tt.err)) {
+				t.Errorf("hata = %v, wantErr %v", err, tt.wantErr)
+			}
+			if begins, rollbacks := len(fake.Queries("BEGIN")), len(fake.Queries("ROLLBACK")); begins != attempts || rollbacks != attempts-boolInt(!tt.wantErr) {
+				t.Errorf("BEGIN %d, ROLLBACK %d; %d deneme", begins, rollbacks, attempts)
+			}
+		})
+	}
+}
+
+func TestRunInTxRetriesCommitFailure(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	failed := false
+	fake.fail = func(query string) error {
+		if query == "COMMIT" && !failed {
+			failed = true
+			return &pgconn.PgError{Code: pgSerializationFailure}
+		}
+		return nil
+	}
+	attempts := 0
+	err := NewTxManager(db, 1).RunInTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("hata = %v, deneme = %d; commit hatası tekrar denenmeli", err, attempts)
+	}
+}
+
+func TestRunInTxCancelDuringBackoff(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake.fail = func(query string) error {
+		if query == "UPDATE x" {
+			cancel()
+			return &pgconn.PgError{Code: pgSerializationFailure}
+		}
+		return nil
+	}
+	manager := NewTxManager(db, 10)
+
+	attempts := 0
+	start := time.Now()
+	err := manager.RunInTx(ctx, func(ctx context.Context) error {
+		attempts++
+		return execIn(ctx, db, "UPDATE x")
+	})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) || pgErrorCode(err) != pgSerializationFailure {
+		t.Errorf("hata = %v; çakışma ve iptal hatası birlikte bekleniyordu", err)
+	}
+	if attempts != 1 {
+		t.Errorf("iptalden sonra %d kez denendi", attempts)
+	}
+	if elapsed >= txRetryBaseDelay {
+		t.Errorf("iptal beklemeyi kesmedi: %v sürdü", elapsed)
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -1,16 +1,26 @@
 package repositories
 
 import (
+	"context"
+	"errors"
+
 	"zatrano/models"
+	"zatrano/pkg/customerrors"
 
 	"gorm.io/gorm"
 )
 
+// IUserRepository kullanıcı verisine erişimin tek noktasıdır; hem AuthService
+// hem UserService bunu kullanır. Listeleme, sayım ve soft delete
+// BaseRepository'den gelir.
 type IUserRepository interface {
 	IBaseRepository[models.User]
-	FindUserByAccount(account string) (*models.User, error)
-	FindUserByID(id uint) (*models.User, error)
-	UpdateUser(user *models.User) error
+	FindUserByAccount(ctx context.Context, account string) (*models.User, error)
+	FindUserByID(ctx context.Context, id uint) (*models.User, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	UpdateUserFields(ctx context.Context, id uint, version uint, data map[string]interface{}, updatedBy uint) error
+	UpdateUserFieldsUnversioned(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	ExistingAccounts(ctx context.Context, accounts []string) (map[string]bool, error)
 }
 
 type UserRepository struct {
@@ -21,26 +31,99 @@ func NewUserRepository(db *gorm.DB) IUserRepository {
 	return &UserRepository{BaseRepository: BaseRepository[models.User]{db: db}}
 }
 
-func (r *UserRepository) FindUserByAccount(account string) (*models.User, error) {
+// Kimlik doğrulama okumaları, şifre veya durum değişikliklerinin replika
+// gecikmesi yüzünden görünmemesini önlemek için birincil veritabanından yapılır.
+func (r *UserRepository) FindUserByAccount(ctx context.Context, account string) (*models.User, error) {
 	var user models.User
-	err := r.db.Where("account = ?", account).First(&user).Error
+	err := primary(ctx, r.db).Where("account = ?", account).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, customerrors.ErrRepoRecordNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) FindUserByID(id uint) (*models.User, error) {
-	var user models.User
-	err := r.db.First(&user, id).Error
-	if err != nil {
-		return nil, err
+// ExistingAccounts accounts içinden silinmemiş bir kullanıcıda kullanılanları
+// döner. Liste parametre sınırını aşmamak için parçalar halinde sorgulanır.
+func (r *UserRepository) ExistingAccounts(ctx context.Context, accounts []string) (map[string]bool, error) {
+	const chunkSize = 1000
+	existing := make(map[string]bool)
+	for start := 0; start < len(accounts); start += chunkSize {
+		end := min(start+chunkSize, len(accounts))
+		var found []string
+		err := primary(ctx, r.db).Model(&models.User{}).
+			Where("account IN ?", accounts[start:end]).
+			Pluck("account", &found).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range found {
+			existing[account] = true
+		}
 	}
-	return &user, nil
+	return existing, nil
+}
+
+func (r *UserRepository) FindUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return r.GetByID(ReadYourWrites(ctx), id)
+}
+
+// UpdateUser kullanıcının tüm alanlarını, okunduğu sürüm hâlâ geçerliyse
+// kaydeder. Başarılı olursa user.Version yeni sürüme güncellenir.
+func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	result := primary(ctx, r.db).Model(user).
+		Where("version = ?", user.Version).
+		Updates(map[string]interface{}{
+			"name":     user.Name,
+			"account":  user.Account,
+			"password": user.Password,
+			"status":   user.Status,
+			"type":     user.Type,
+			"version":  gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return r.versionMismatch(ctx, user.ID)
+	}
+	user.Version++
+	return nil
+}
+
+// UpdateUserFields verilen alanları, kaydın sürümü hâlâ version ise günceller
+// ve updated_by'ı işlemi yapan kullanıcıya ayarlar (bkz. UpdateVersioned).
+// version 0 ise ErrVersionRequired döner.
+func (r *UserRepository) UpdateUserFields(ctx context.Context, id uint, version uint, data map[string]interface{}, updatedBy uint) error {
+	if updatedBy == 0 {
+		return customerrors.ErrInvalidUserContext
+	}
+	if version == 0 {
+		return customerrors.ErrVersionRequired
+	}
+	return r.UpdateVersioned(ctx, id, version, withUpdatedBy(data, updatedBy))
 }
 
-func (r *UserRepository) UpdateUser(user *models.User) error {
-	return r.db.Save(user).Error
+// UpdateUserFieldsUnversioned sürüm kontrolü yapmadan günceller. Yalnızca
+// kullanıcının başkasıyla aynı anda düzenlemediği alanlar (ör. kendi
+// tercihleri) ve sistem işlemleri için kullanılır; formdan gelen düzenlemeler
+// UpdateUserFields kullanmalıdır.
+func (r *UserRepository) UpdateUserFieldsUnversioned(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	if updatedBy == 0 {
+		return customerrors.ErrInvalidUserContext
+	}
+	return r.Update(ctx, id, withUpdatedBy(data, updatedBy))
+}
+
+func withUpdatedBy(data map[string]interface{}, updatedBy uint) map[string]interface{} {
+	fields := make(map[string]interface{}, len(data)+1)
+	for key, value := range data {
+		fields[key] = value
+	}
+	fields["updated_by"] = updatedBy
+	return fields
 }
 
 var _ IUserRepository = (*UserRepository)(nil)
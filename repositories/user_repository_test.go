@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"zatrano/models"
+	"zatrano/pkg/customerrors"
+)
+
+func TestUpdateUserFieldsRequiresVersion(t *testing.T) {
+	db, fake := newFakeGorm(t, nil)
+	repo := NewUserRepository(db)
+	ctx := models.WithSystemActor(context.Background())
+	data := map[string]interface{}{"name": "Ayşe"}
+
+	if err := repo.UpdateUserFields(ctx, 5, 0, data, 1); !errors.Is(err, customerrors.ErrVersionRequired) {
+		t.Fatalf("sürümsüz güncelleme: hata = %v, ErrVersionRequired bekleniyordu", err)
+	}
+	if queries := fake.Queries("UPDATE"); len(queries) != 0 {
+		t.Fatalf("sürümsüz güncelleme veritabanına gitti: %v", queries)
+	}
+
+	if err := repo.UpdateUserFields(ctx, 5, 3, data, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateUserFieldsUnversioned(ctx, 5, data, 1); err != nil {
+		t.Fatal(err)
+	}
+	updates := fake.Queries("UPDATE")
+	if len(updates) != 2 {
+		t.Fatalf("%d güncelleme sorgusu çalıştı, 2 bekleniyordu", len(updates))
+	}
+	if !strings.Contains(updates[0].SQL, "version = $") {
+		t.Errorf("sürümlü güncelleme sürümü kontrol etmiyor: %s", updates[0].SQL)
+	}
+	if strings.Contains(updates[1].SQL, "version = $") {
+		t.Errorf("sürümsüz güncelleme sürümü kontrol ediyor: %s", updates[1].SQL)
+	}
+	for _, update := range updates {
+		if !strings.Contains(update.SQL, `"version"=version + 1`) || !strings.Contains(update.SQL, `"updated_by"=$`) {
+			t.Errorf("sürüm artırılmıyor veya updated_by yazılmıyor: %s", update.SQL)
+		}
+	}
+}
+
+func TestUserRepositoryList(t *testing.T) {
+	db, fake := newFakeGorm(t, func(query string) fakeRows {
+		if strings.HasPrefix(query, "SELECT count(*)") {
+			return fakeRows{Columns: []string{"count"}, Rows: [][]driver.Value{{int64(5)}}}
+		}
+		return fakeRows{Columns: []string{"id", "name", "account", "status", "type"}, Rows: [][]driver.Value{
+			{int64(9), "Ayşe", "ayse", true, "panel"},
+			{int64(8), "Aydın", "aydin", true, "panel"},
+			{int64(7), "Ayten", "ayten", true, "panel"},
+		}}
+	})
+	repo := NewUserRepository(db)
+	params := userListParams(t, map[string]string{
+		"filter[name]": "AY", "filter[status]": "true", "filter[type]": "panel", "page": "2", "perPage": "2",
+	})
+
+	users, meta, err := repo.List(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const where = `WHERE tr_fold(name) LIKE $1 AND "status" = $2 AND "type" = $3 AND "users"."deleted_at" IS NULL`
+	counts := fake.Queries("SELECT count(*)")
+	if len(counts) != 1 || !strings.HasSuffix(counts[0].SQL, where) {
+		t.Fatalf("sayım sorgusu: %v", counts)
+	}
+	selects := fake.Queries(`SELECT * FROM "users"`)
+	if len(selects) != 1 || selects[0].SQL != `SELECT * FROM "users" `+where+` ORDER BY "id" DESC LIMIT $4 OFFSET $5` {
+		t.Fatalf("liste sorgusu: %v", selects)
+	}
+	if got, want := fmt.Sprint(selects[0].Args), "[%ay% true panel 3 2]"; got != want {
+		t.Errorf("args = %s, want %s", got, want)
+	}
+
+	if len(users) != 2 || users[0].ID != 9 || users[1].ID != 8 || users[0].Name != "Ayşe" || users[0].Type != models.Panel {
+		t.Errorf("kullanıcılar = %+v", users)
+	}
+	if meta.TotalItems != 5 || meta.TotalPages != 3 || meta.CurrentPage != 2 || !meta.HasNext || !meta.HasPrev {
+		t.Errorf("meta = %+v", meta)
+	}
+	if !strings.Contains(meta.Next, "page=3") || !strings.Contains(meta.Prev, "page=1") || !strings.Contains(meta.Next, "filter%5Bstatus%5D=true") {
+		t.Errorf("sayfa bağlantıları filtreleri korumuyor: next %q, prev %q", meta.Next, meta.Prev)
+	}
+}
+
+func TestUserRepositoryFindUserByAccount(t *testing.T) {
+	found := true
+	db, fake := newFakeGorm(t, func(string) fakeRows {
+		if !found {
+			return fakeRows{Columns: []string{"id"}}
+		}
+		return fakeRows{Columns: []string{"id", "account", "password", "status", "type", "version"}, Rows: [][]driver.Value{
+			{int64(3), "ayse", "$2a$10$hash", false, "dashboard", int64(4)},
+		}}
+	})
+	repo := NewUserRepository(db)
+
+	user, err := repo.FindUserByAccount(context.Background(), "ayse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 3 || user.Account != "ayse" || user.Password != "$2a$10$hash" || user.Status || user.Type != models.Dashboard || user.Version != 4 {
+		t.Errorf("kullanıcı = %+v", user)
+	}
+	queries := fake.Queries("SELECT")
+	want := `SELECT * FROM "users" WHERE account = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`
+	if len(queries) != 1 || queries[0].SQL != want || queries[0].Args[0] != "ayse" {
+		t.Errorf("sorgu = %v, want %s", queries, want)
+	}
+
+	found = false
+	if _, err := repo.FindUserByAccount(context.Background(), "ghost"); !errors.Is(err, customerrors.ErrRepoRecordNotFound) {
+		t.Errorf("olmayan hesap: hata = %v, ErrRepoRecordNotFound bekleniyordu", err)
+	}
+}
+
+func TestUserRepositorySoftDelete(t *testing.T) {
+	exists := true
+	db, fake := newFakeGorm(t, func(query string) fakeRows {
+		if strings.HasPrefix(query, "SELECT count(*)") {
+			return fakeRows{Columns: []string{"count"}, Rows: [][]driver.Value{{int64(1)}}}
+		}
+		if !exists {
+			return fakeRows{Columns: []string{"id"}}
+		}
+		return fakeRows{Columns: []string{"id", "account"}, Rows: [][]driver.Value{{int64(7), "ayse"}}}
+	})
+	repo := NewUserRepository(db)
+	ctx := models.WithSystemActor(context.Background())
+
+	if err := repo.Delete(ctx, 7); !errors.Is(err, customerrors.ErrInvalidUserContext) {
+		t.Fatalf("işlemi yapan olmadan silme: hata = %v", err)
+	}
+	if updates := fake.Queries("UPDATE"); len(updates) != 0 {
+		t.Fatalf("işlemi yapan olmadan silme veritabanına yazdı: %v", updates)
+	}
+
+	ctx = context.WithValue(ctx, "user_id", uint(2))
+	if err := repo.Delete(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	if deletes := fake.Queries("DELETE"); len(deletes) != 0 {
+		t.Errorf("kullanıcı kalıcı olarak silindi: %v", deletes)
+	}
+	updates := fake.Queries("UPDATE")
+	if len(updates) != 2 {
+		t.Fatalf("%d güncelleme sorgusu çalıştı, 2 bekleniyordu: %v", len(updates), updates)
+	}
+	if !strings.HasPrefix(updates[0].SQL, `UPDATE "users" SET "deleted_by"=$1`) || updates[0].Args[0] != int64(2) {
+		t.Errorf("deleted_by yazılmadı: %v", updates[0])
+	}
+	if want := `UPDATE "users" SET "deleted_at"=$1 WHERE "users"."id" = $2 AND "users"."deleted_at" IS NULL`; updates[1].SQL != want {
+		t.Errorf("soft delete sorgusu = %s, want %s", updates[1].SQL, want)
+	}
+
+	if err := repo.Restore(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	restores := fake.Queries("UPDATE")[2:]
+	if len(restores) != 1 || !strings.HasPrefix(restores[0].SQL, `UPDATE "users" SET "deleted_at"=$1,"deleted_by"=$2`) ||
+		!strings.HasSuffix(restores[0].SQL, "WHERE id = $5 AND deleted_at IS NOT NULL") || restores[0].Args[0] != nil {
+		t.Errorf("geri yükleme sorgusu: %v", restores)
+	}
+
+	if _, _, err := repo.ListTrashed(ctx, userListParams(t, map[string]string{})); err != nil {
+		t.Fatal(err)
+	}
+	trashed := fake.Queries(`SELECT * FROM "users"`)
+	last := trashed[len(trashed)-1].SQL
+	if !strings.Contains(last, "WHERE deleted_at IS NOT NULL") || strings.Contains(last, `"users"."deleted_at" IS NULL`) {
+		t.Errorf("çöp kutusu sorgusu: %s", last)
+	}
+
+	exists = false
+	if err := repo.Delete(ctx, 8); !errors.Is(err, customerrors.ErrRepoRecordNotFound) {
+		t.Errorf("olmayan kullanıcıyı silme: hata = %v", err)
+	}
+}
+
+func TestUserRepositoryExistingAccounts(t *testing.T) {
+	db, fake := newFakeGorm(t, func(string) fakeRows {
+		return fakeRows{Columns: []string{"account"}, Rows: [][]driver.Value{{"a1"}, {"a1200"}}}
+	})
+	repo := NewUserRepository(db)
+
+	accounts := make([]string, 1500)
+	for i := range accounts {
+		accounts[i] = fmt.Sprintf("a%d", i)
+	}
+	existing, err := repo.ExistingAccounts(context.Background(), accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(existing, map[string]bool{"a1": true, "a1200": true}) {
+		t.Errorf("existing = %v", existing)
+	}
+	queries := fake.Queries("SELECT")
+	if len(queries) != 2 || len(queries[0].Args) != 1000 || len(queries[1].Args) != 500 {
+		t.Fatalf("hesaplar 1000'lik parçalarla sorgulanmadı: %d sorgu", len(queries))
+	}
+	if !strings.Contains(queries[0].SQL, `"users"."deleted_at" IS NULL`) {
+		t.Errorf("silinmiş kullanıcılar hariç tutulmuyor: %s", queries[0].SQL)
+	}
+}
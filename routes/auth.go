@@ -7,15 +7,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerAuthRoutes(app *fiber.App) {
-	authHandler := handlers.NewAuthHandler()
-
+func registerAuthRoutes(app *fiber.App, authHandler *handlers.AuthHandler, mw *middlewares.Middleware) {
 	authGroup := app.Group("/auth")
 
-	authGroup.Get("/login", middlewares.GuestMiddleware, authHandler.ShowLogin)
-	authGroup.Post("/login", middlewares.GuestMiddleware, authHandler.Login)
+	authGroup.Get("/login", mw.Guest, authHandler.ShowLogin)
+	authGroup.Post("/login", mw.Guest, authHandler.Login)
 
-	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
-	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
-	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, authHandler.UpdatePassword)
+	authGroup.Get("/logout", mw.Auth, authHandler.Logout)
+	authGroup.Get("/profile", mw.Auth, authHandler.Profile)
+	authGroup.Post("/profile/update-password", mw.Auth, authHandler.UpdatePassword)
+	authGroup.Post("/profile/preferences", mw.Auth, authHandler.UpdatePreferences)
 }
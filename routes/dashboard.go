@@ -8,23 +8,36 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerDashboardRoutes(app *fiber.App) {
+func registerDashboardRoutes(app *fiber.App, dashboardHomeHandler *handlers.DashboardHomeHandler, userHandler *handlers.UserHandler, userImportHandler *handlers.UserImportHandler, auditLogHandler *handlers.AuditLogHandler, mw *middlewares.Middleware) {
 	dashboardGroup := app.Group("/dashboard")
 	dashboardGroup.Use(
-		middlewares.AuthMiddleware,
-		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Dashboard),
+		mw.Auth,
+		mw.Status,
+		mw.Type(models.Dashboard),
 	)
 
-	dashboardHomeHandler := handlers.NewDashboardHomeHandler()
 	dashboardGroup.Get("/home", dashboardHomeHandler.HomePage)
 
-	userHandler := handlers.NewUserHandler()
 	dashboardGroup.Get("/users", userHandler.ListUsers)
+	dashboardGroup.Get("/users/trash", userHandler.ListTrashedUsers)
+	dashboardGroup.Get("/users/export", userHandler.ExportUsers)
+	dashboardGroup.Get("/users/trash/export", userHandler.ExportTrashedUsers)
 	dashboardGroup.Get("/users/create", userHandler.ShowCreateUser)
 	dashboardGroup.Post("/users/create", userHandler.CreateUser)
 	dashboardGroup.Get("/users/update/:id", userHandler.ShowUpdateUser)
 	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
+	dashboardGroup.Post("/users/update/:id/revert/:versionId", userHandler.RevertUserVersion)
 	dashboardGroup.Post("/users/delete/:id", userHandler.DeleteUser)
 	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
+	dashboardGroup.Post("/users/restore/:id", userHandler.RestoreUser)
+	dashboardGroup.Post("/users/purge/:id", userHandler.ForceDeleteUser)
+	dashboardGroup.Post("/users/bulk", userHandler.BulkUserAction)
+	dashboardGroup.Get("/users/import", userImportHandler.ShowImportUsers)
+	dashboardGroup.Post("/users/import", userImportHandler.UploadImportUsers)
+	dashboardGroup.Get("/users/import/preview", userImportHandler.PreviewImportUsers)
+	dashboardGroup.Post("/users/import/run", userImportHandler.RunImportUsers)
+	dashboardGroup.Get("/users/import/result", userImportHandler.DownloadImportResult)
+
+	dashboardGroup.Get("/audit-logs", auditLogHandler.ListAuditLogs)
+	dashboardGroup.Get("/audit-logs/export", auditLogHandler.ExportAuditLogs)
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"zatrano/configs"
+	handlers "zatrano/handlers/health"
+	"zatrano/pkg/logs"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
+)
+
+// registerHealthRoutes liveness ve readiness uç noktalarını herkese açık
+// kaydeder. /healthz/details kontrol hatalarını (veritabanı, bekleyen
+// migrasyonlar) içerdiği için /metrics ile aynı şekilde korunur: METRICS_PORT
+// tanımlıysa yalnızca metrik sunucusunda, değilse basic auth arkasında sunulur.
+func registerHealthRoutes(app *fiber.App, healthHandler *handlers.HealthHandler, cfg configs.MetricsConfig) {
+	app.Get("/healthz", healthHandler.Liveness)
+	app.Get("/readyz", healthHandler.Readiness)
+
+	if cfg.Port != 0 {
+		return
+	}
+	if cfg.Username == "" || cfg.Password == "" {
+		logs.SLog.Warn("METRICS_PORT veya METRICS_USERNAME/METRICS_PASSWORD tanımlı değil, /healthz/details uç noktası devre dışı.")
+		return
+	}
+	app.Get("/healthz/details", basicauth.New(basicauth.Config{
+		Users: map[string]string{cfg.Username: cfg.Password},
+	}), healthHandler.Details)
+}
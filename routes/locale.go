@@ -0,0 +1,13 @@
+package routes
+
+import (
+	handlers "zatrano/handlers/locale"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// registerLocaleRoutes dil seçiciyi kaydeder. Dil değiştirmek cookie
+// yazdığından istek GET değil, CSRF korumalı bir POST formudur.
+func registerLocaleRoutes(app *fiber.App, localeHandler *handlers.LocaleHandler) {
+	app.Post("/locale/:lang", localeHandler.SwitchLocale)
+}
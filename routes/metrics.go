@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"zatrano/configs"
+	healthhandlers "zatrano/handlers/health"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
+)
+
+func registerMetricsRoutes(app *fiber.App, cfg configs.MetricsConfig) {
+	if cfg.Port != 0 {
+		return
+	}
+
+	username := cfg.Username
+	password := cfg.Password
+	if username == "" || password == "" {
+		logs.SLog.Warn("METRICS_PORT veya METRICS_USERNAME/METRICS_PASSWORD tanımlı değil, /metrics uç noktası devre dışı.")
+		return
+	}
+
+	app.Get("/metrics", basicauth.New(basicauth.Config{
+		Users: map[string]string{username: password},
+	}), metrics.Handler())
+}
+
+// NewMetricsApp METRICS_PORT üzerinde dinleyen, yalnızca iç ağdan erişilmesi
+// beklenen uygulamayı kurar; /metrics ve /healthz/details burada sunulur.
+func NewMetricsApp(healthHandler *healthhandlers.HealthHandler) *fiber.App {
+	metricsApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+	metricsApp.Get("/metrics", metrics.Handler())
+	metricsApp.Get("/healthz/details", healthHandler.Details)
+	return metricsApp
+}
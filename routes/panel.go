@@ -8,12 +8,12 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func registerPanelRoutes(app *fiber.App) {
+func registerPanelRoutes(app *fiber.App, mw *middlewares.Middleware) {
 	panelGroup := app.Group("/panel")
 	panelGroup.Use(
-		middlewares.AuthMiddleware,
-		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Panel),
+		mw.Auth,
+		mw.Status,
+		mw.Type(models.Panel),
 	)
 
 	panelGroup.Get("/home", handlers.PanelHomeHandler)
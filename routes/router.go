@@ -1,27 +1,60 @@
 package routes
 
 import (
+	"time"
+
 	"zatrano/configs"
+	authhandlers "zatrano/handlers/auth"
+	dashboardhandlers "zatrano/handlers/dashboard"
+	healthhandlers "zatrano/handlers/health"
+	localehandlers "zatrano/handlers/locale"
+	"zatrano/middlewares"
 	"zatrano/models"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/metrics"
 	"zatrano/pkg/sessions"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
-	"gorm.io/gorm"
+	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
-func SetupRoutes(app *fiber.App, db *gorm.DB) {
-	app.Use(logger.New())
+type Dependencies struct {
+	SessionStore *session.Store
+	Metrics      configs.MetricsConfig
+	QueryTimeout time.Duration
+	Middleware   *middlewares.Middleware
+
+	AuthHandler          *authhandlers.AuthHandler
+	DashboardHomeHandler *dashboardhandlers.DashboardHomeHandler
+	UserHandler          *dashboardhandlers.UserHandler
+	AuditLogHandler      *dashboardhandlers.AuditLogHandler
+	UserImportHandler    *dashboardhandlers.UserImportHandler
+	HealthHandler        *healthhandlers.HealthHandler
+	LocaleHandler        *localehandlers.LocaleHandler
+}
+
+func SetupRoutes(app *fiber.App, deps Dependencies) {
+	app.Use(middlewares.RequestIDMiddleware)
+	app.Use(middlewares.QueryTimeout(deps.QueryTimeout))
+	app.Use(metrics.HTTPMiddleware)
+	app.Use(logger.New(logger.Config{
+		Format: "${time} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${locals:requestid} | ${error}\n",
+	}))
+
+	registerHealthRoutes(app, deps.HealthHandler, deps.Metrics)
 
-	sessionStore := configs.SetupSession()
-	app.Use(func(c *fiber.Ctx) error {
-		c.Locals("session", sessionStore)
-		return c.Next()
-	})
+	app.Use(sessions.Middleware(deps.SessionStore))
+	app.Use(flashmessages.Middleware)
+	app.Use(i18n.Middleware)
+	app.Use(configs.SetupCSRF())
 
-	registerAuthRoutes(app)
-	registerDashboardRoutes(app)
-	registerPanelRoutes(app)
+	registerMetricsRoutes(app, deps.Metrics)
+	registerLocaleRoutes(app, deps.LocaleHandler)
+	registerAuthRoutes(app, deps.AuthHandler, deps.Middleware)
+	registerDashboardRoutes(app, deps.DashboardHomeHandler, deps.UserHandler, deps.UserImportHandler, deps.AuditLogHandler, deps.Middleware)
+	registerPanelRoutes(app, deps.Middleware)
 
 	app.Use(rootRedirector)
 }
@@ -48,6 +81,6 @@ func rootRedirector(c *fiber.Ctx) error {
 	case models.Dashboard:
 		return c.Redirect("/dashboard/home")
 	default:
-		return c.SendString("Geçersiz kullanıcı tipi")
+		return c.SendString(i18n.From(c).T("access.invalid_user_type"))
 	}
 }
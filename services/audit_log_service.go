@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"zatrano/models"
+	"zatrano/pkg/customerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+type IAuditLogService interface {
+	GetAuditLogs(ctx context.Context, params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	StreamAuditLogs(ctx context.Context, params queryparams.ListParams, fn func(entry *models.AuditLog) error) error
+}
+
+type AuditLogService struct {
+	repo repositories.IAuditLogRepository
+}
+
+func NewAuditLogService(repo repositories.IAuditLogRepository) IAuditLogService {
+	return &AuditLogService{repo: repo}
+}
+
+func (s *AuditLogService) GetAuditLogs(ctx context.Context, params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	entries, meta, err := s.repo.List(ctx, normalizeListParams(params))
+	if err != nil {
+		logs.Log.Error("GetAuditLogs: Repository hatası", zap.Error(err))
+		return nil, customerrors.ErrAuditLogListFailed
+	}
+	return &queryparams.PaginatedResult{Data: entries, Meta: meta}, nil
+}
+
+// StreamAuditLogs listeyle aynı filtre ve sıralamadaki tüm audit kayıtlarını
+// sayfalamadan fn'e verir.
+func (s *AuditLogService) StreamAuditLogs(ctx context.Context, params queryparams.ListParams, fn func(entry *models.AuditLog) error) error {
+	if err := s.repo.Stream(ctx, params, fn); err != nil {
+		logs.Log.Error("StreamAuditLogs: Audit kayıtları okunurken hata", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+var _ IAuditLogService = (*AuditLogService)(nil)
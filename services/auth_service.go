@@ -1,14 +1,19 @@
 package services
 
 import (
+	"context"
+	"errors"
+
 	"zatrano/models"
+	"zatrano/pkg/constants"
 	"zatrano/pkg/customerrors"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
 	"zatrano/repositories"
 
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 )
 
 type ServiceError string
@@ -18,30 +23,33 @@ func (e ServiceError) Error() string {
 }
 
 type IAuthService interface {
-	Authenticate(account, password string) (*models.User, error)
-	GetUserProfile(id uint) (*models.User, error)
-	UpdatePassword(userID uint, currentPass, newPassword string) error
+	Authenticate(ctx context.Context, account, password string) (*models.User, error)
+	GetUserProfile(ctx context.Context, id uint) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error
+	UpdatePreferences(ctx context.Context, userID uint, locale, timezone string) error
 }
 
 type AuthService struct {
-	repo repositories.IAuthRepository
+	repo repositories.IUserRepository
 }
 
-func NewAuthService() IAuthService {
-	return &AuthService{repo: repositories.NewAuthRepository()}
+func NewAuthService(repo repositories.IUserRepository) IAuthService {
+	return &AuthService{repo: repo}
 }
 
-func (s *AuthService) Authenticate(account, password string) (*models.User, error) {
-	user, err := s.repo.FindUserByAccount(account)
+func (s *AuthService) Authenticate(ctx context.Context, account, password string) (*models.User, error) {
+	user, err := s.repo.FindUserByAccount(ctx, account)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
 			logs.Log.Warn("Kimlik doğrulama başarısız: Kullanıcı bulunamadı", zap.String("account", account))
+			metrics.RecordLoginAttempt(metrics.ResultInvalidCredentials)
 			return nil, customerrors.ErrInvalidCredentials
 		}
 		logs.Log.Error("Kimlik doğrulama hatası (DB)",
 			zap.String("account", account),
 			zap.Error(err),
 		)
+		metrics.RecordLoginAttempt(metrics.ResultError)
 		return nil, customerrors.ErrAuthGeneric
 	}
 
@@ -50,6 +58,7 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
+		metrics.RecordLoginAttempt(metrics.ResultInactive)
 		return nil, customerrors.ErrUserInactive
 	}
 
@@ -59,9 +68,11 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
+		metrics.RecordLoginAttempt(metrics.ResultInvalidCredentials)
 		return nil, customerrors.ErrInvalidCredentials
 	}
 
+	metrics.RecordLoginAttempt(metrics.ResultSuccess)
 	logs.Log.Info("Kimlik doğrulama başarılı",
 		zap.String("account", account),
 		zap.Uint("user_id", user.ID),
@@ -69,10 +80,10 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 	return user, nil
 }
 
-func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
-	user, err := s.repo.FindUserByID(id)
+func (s *AuthService) GetUserProfile(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.FindUserByID(ctx, id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
 			logs.Log.Warn("Profil alınamadı: Kullanıcı bulunamadı", zap.Uint("user_id", id))
 			return nil, customerrors.ErrUserNotFound
 		}
@@ -85,10 +96,10 @@ func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
 	return user, nil
 }
 
-func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword string) error {
-	user, err := s.repo.FindUserByID(userID)
+func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error {
+	user, err := s.repo.FindUserByID(ctx, userID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
 			logs.Log.Warn("Parola güncelleme başarısız: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			return customerrors.ErrUserNotFound
 		}
@@ -104,7 +115,7 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return customerrors.ErrCurrentPasswordIncorrect
 	}
 
-	if len(newPassword) < 6 {
+	if len(newPassword) < constants.MinPasswordLength {
 		logs.Log.Warn("Parola güncelleme başarısız: Yeni parola çok kısa", zap.Uint("user_id", userID))
 		return customerrors.ErrPasswordTooShort
 	}
@@ -123,7 +134,7 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 	}
 
 	user.Password = string(hashedPassword)
-	if err := s.repo.UpdateUser(user); err != nil {
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
 		logs.Log.Error("Parola güncelleme hatası: Kullanıcı güncellenirken DB hatası",
 			zap.Uint("user_id", userID),
 			zap.Error(err),
@@ -135,4 +146,42 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 	return nil
 }
 
+// UpdatePreferences kullanıcının arayüz dili ve saat dilimi tercihlerini
+// kaydeder. Boş değer varsayılanın kullanılacağı anlamına gelir; dil
+// desteklenen bir dile indirgenerek saklanır (ör. "en-US" → "en").
+func (s *AuthService) UpdatePreferences(ctx context.Context, userID uint, locale, timezone string) error {
+	if locale != "" {
+		tag, ok := i18n.Default().Match(locale)
+		if !ok {
+			return customerrors.ErrInvalidLocale
+		}
+		locale = tag.String()
+	}
+	if timezone != "" {
+		if _, ok := i18n.LoadLocation(timezone); !ok {
+			return customerrors.ErrInvalidTimezone
+		}
+	}
+
+	data := map[string]interface{}{"locale": locale, "timezone": timezone}
+	if err := s.repo.UpdateUserFieldsUnversioned(ctx, userID, data, userID); err != nil {
+		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
+			logs.Log.Warn("Tercih güncelleme başarısız: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
+			return customerrors.ErrUserNotFound
+		}
+		logs.Log.Error("Tercih güncelleme hatası: Kullanıcı güncellenirken DB hatası",
+			zap.Uint("user_id", userID),
+			zap.Error(err),
+		)
+		return customerrors.ErrDatabaseUpdateFailed
+	}
+
+	logs.Log.Info("Kullanıcı tercihleri güncellendi",
+		zap.Uint("user_id", userID),
+		zap.String("locale", locale),
+		zap.String("timezone", timezone),
+	)
+	return nil
+}
+
 var _ IAuthService = (*AuthService)(nil)
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"zatrano/models"
+	"zatrano/pkg/constants"
+	"zatrano/pkg/customerrors"
+	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
+	"zatrano/pkg/turkishsearch"
+
+	"go.uber.org/zap"
+)
+
+// userImportBatchSize her transaction'da oluşturulan en fazla kullanıcı
+// sayısıdır; hatalı bir satır yalnızca kendi savepoint'ini geri alır.
+const userImportBatchSize = 100
+
+// UserImportRow dosyadan okunmuş, henüz doğrulanmamış bir kullanıcı satırıdır.
+type UserImportRow struct {
+	Line     int
+	Name     string
+	Account  string
+	Password string
+	Type     string
+	Status   string
+}
+
+// UserImportResult bir satırın doğrulama ve içe aktarma sonucudur. User,
+// satırdan çözülen değerleri; içe aktarıldıysa oluşturulan kaydı taşır.
+type UserImportResult struct {
+	Row     UserImportRow
+	User    models.User
+	Errors  []error
+	Created bool
+}
+
+func (r UserImportResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// UserImportReport bir önizlemenin veya içe aktarmanın tüm satır sonuçlarıdır.
+type UserImportReport struct {
+	Results []UserImportResult
+	Valid   int
+	Invalid int
+	Created int
+}
+
+func newUserImportReport(results []UserImportResult) *UserImportReport {
+	report := &UserImportReport{Results: results}
+	for _, result := range results {
+		if result.Valid() {
+			report.Valid++
+		} else {
+			report.Invalid++
+		}
+		if result.Created {
+			report.Created++
+		}
+	}
+	return report
+}
+
+// PreviewUserImport satırları veritabanına yazmadan doğrular.
+func (s *UserService) PreviewUserImport(ctx context.Context, rows []UserImportRow) (*UserImportReport, error) {
+	results, err := s.validateUserImport(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	return newUserImportReport(results), nil
+}
+
+// ImportUsers satırları doğrular ve geçerli olanları userImportBatchSize'lık
+// transaction'larla oluşturur. Geçersiz satırlar atlanır; bir satırın
+// kaydedilememesi aynı partideki diğer satırları etkilemez.
+func (s *UserService) ImportUsers(ctx context.Context, rows []UserImportRow) (*UserImportReport, error) {
+	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
+	if !ok || currentUserID == 0 {
+		logs.Log.Error("ImportUsers: Context'te geçerli user_id bulunamadı veya 0.", zap.Any("value", ctx.Value(contextUserIDKey)))
+		return nil, customerrors.ErrContextUserIDNotFound
+	}
+
+	results, err := s.validateUserImport(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var valid []int
+	for i := range results {
+		if results[i].Valid() {
+			valid = append(valid, i)
+		}
+	}
+	logs.Log.Info("Kullanıcılar içe aktarılıyor...",
+		zap.Int("rows", len(rows)),
+		zap.Int("valid", len(valid)),
+		zap.Uint("actor_user_id", currentUserID),
+	)
+
+	hashUserImportPasswords(results, valid)
+	hashed := valid[:0]
+	for _, i := range valid {
+		if results[i].Valid() {
+			hashed = append(hashed, i)
+		}
+	}
+	valid = hashed
+
+	for start := 0; start < len(valid); start += userImportBatchSize {
+		batch := valid[start:min(start+userImportBatchSize, len(valid))]
+		s.importUserBatch(ctx, results, batch)
+	}
+
+	report := newUserImportReport(results)
+	logs.Log.Info("Kullanıcı içe aktarma tamamlandı",
+		zap.Int("created", report.Created),
+		zap.Int("invalid", report.Invalid),
+	)
+	return report, nil
+}
+
+// importUserBatch batch'teki satırları tek transaction'da, her birini kendi
+// savepoint'inde oluşturur. Transaction yeniden denenebileceği için sonuçlar
+// yalnızca transaction başarıyla bittikten sonra results'a yazılır.
+func (s *UserService) importUserBatch(ctx context.Context, results []UserImportResult, batch []int) {
+	var (
+		created map[int]models.User
+		failed  map[int]error
+	)
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		created, failed = make(map[int]models.User, len(batch)), make(map[int]error)
+		for _, i := range batch {
+			user := results[i].User
+			err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+				return s.repo.Create(ctx, &user)
+			})
+			if err != nil {
+				failed[i] = err
+				continue
+			}
+			created[i] = user
+		}
+		return nil
+	})
+	if err != nil {
+		logs.Log.Error("Kullanıcı içe aktarma partisi kaydedilemedi", zap.Int("rows", len(batch)), zap.Error(err))
+		created, failed = nil, make(map[int]error, len(batch))
+		for _, i := range batch {
+			failed[i] = err
+		}
+	}
+
+	for _, i := range batch {
+		if user, ok := created[i]; ok {
+			results[i].User = user
+			results[i].Created = true
+			metrics.RecordUserOperation(metrics.UserOperationCreate, nil)
+			continue
+		}
+		rowErr := failed[i]
+		metrics.RecordUserOperation(metrics.UserOperationCreate, rowErr)
+		logs.Log.Warn("İçe aktarılan kullanıcı oluşturulamadı",
+			zap.Int("line", results[i].Row.Line),
+			zap.String("account", results[i].User.Account),
+			zap.Error(rowErr),
+		)
+		results[i].Errors = append(results[i].Errors, customerrors.ErrUserCreationFailed)
+	}
+}
+
+// hashUserImportPasswords geçerli satırların şifrelerini paralel olarak
+// hashler; bcrypt satır başına onlarca milisaniye sürdüğü için yüzlerce
+// kullanıcıda sıralı hashleme isteği gereksiz uzatır.
+func hashUserImportPasswords(results []UserImportResult, valid []int) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := results[i].User.SetPassword(results[i].User.Password); err != nil {
+					results[i].Errors = append(results[i].Errors, customerrors.ErrPasswordHashingFailed)
+				}
+			}
+		}()
+	}
+	for _, i := range valid {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func (s *UserService) validateUserImport(ctx context.Context, rows []UserImportRow) ([]UserImportResult, error) {
+	if len(rows) == 0 {
+		return nil, customerrors.ErrUserImportEmpty
+	}
+
+	results := make([]UserImportResult, len(rows))
+	firstLine := make(map[string]int, len(rows))
+	accounts := make([]string, 0, len(rows))
+
+	for i, row := range rows {
+		result := &results[i]
+		result.Row = row
+		addError := func(err error) {
+			result.Errors = append(result.Errors, err)
+		}
+
+		result.User.Name = row.Name
+		switch {
+		case row.Name == "":
+			addError(customerrors.ErrImportNameRequired)
+		case utf8.RuneCountInString(row.Name) > 100:
+			addError(customerrors.ErrImportNameTooLong.With(100))
+		}
+
+		result.User.Account = row.Account
+		switch {
+		case row.Account == "":
+			addError(customerrors.ErrImportAccountRequired)
+		case utf8.RuneCountInString(row.Account) > 100:
+			addError(customerrors.ErrImportAccountTooLong.With(100))
+		default:
+			if line, ok := firstLine[row.Account]; ok {
+				addError(customerrors.ErrImportAccountDuplicate.With(line))
+			} else {
+				firstLine[row.Account] = row.Line
+				accounts = append(accounts, row.Account)
+			}
+		}
+
+		result.User.Password = row.Password
+		switch {
+		case row.Password == "":
+			addError(customerrors.ErrImportPasswordRequired)
+		case len(row.Password) < constants.MinPasswordLength:
+			addError(customerrors.ErrImportPasswordTooShort.With(constants.MinPasswordLength))
+		case len(row.Password) > 72:
+			addError(customerrors.ErrImportPasswordTooLong.With(72))
+		}
+
+		userType, ok := parseImportUserType(row.Type)
+		if !ok {
+			addError(customerrors.ErrImportInvalidUserType.With(row.Type))
+		}
+		result.User.Type = userType
+
+		status, ok := parseImportStatus(row.Status)
+		if !ok {
+			addError(customerrors.ErrImportInvalidUserStatus.With(row.Status))
+		}
+		result.User.Status = status
+	}
+
+	existing, err := s.repo.ExistingAccounts(ctx, accounts)
+	if err != nil {
+		logs.Log.Error("Kullanıcı içe aktarma: Mevcut hesaplar kontrol edilemedi", zap.Error(err))
+		return nil, customerrors.ErrUserImportFailed
+	}
+	for i := range results {
+		if existing[results[i].Row.Account] {
+			results[i].Errors = append(results[i].Errors, customerrors.ErrImportAccountExists)
+		}
+	}
+	return results, nil
+}
+
+// parseImportUserType boş değeri panel kabul eder; formdaki Türkçe ve
+// İngilizce etiketler de tanınır. Değerler Türkçe kurallarla katlanır;
+// "YÖNETİCİ", "yönetici" ve "yonetici" aynıdır.
+func parseImportUserType(value string) (models.UserType, bool) {
+	switch turkishsearch.Fold(strings.TrimSpace(value)) {
+	case "", "panel", "kullanici", "user":
+		return models.Panel, true
+	case "dashboard", "yonetici", "administrator":
+		return models.Dashboard, true
+	}
+	return models.Panel, false
+}
+
+// parseImportStatus boş değeri aktif kabul eder.
+func parseImportStatus(value string) (bool, bool) {
+	switch turkishsearch.Fold(strings.TrimSpace(value)) {
+	case "", "1", "true", "aktif", "evet", "active", "yes":
+		return true, true
+	case "0", "false", "pasif", "hayir", "inactive", "no":
+		return false, true
+	}
+	return true, false
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"zatrano/models"
+	"zatrano/pkg/customerrors"
+	"zatrano/pkg/logs"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+// importUserRepository yalnızca içe aktarma doğrulamasının kullandığı
+// ExistingAccounts'u uygular.
+type importUserRepository struct {
+	repositories.IUserRepository
+	existing map[string]bool
+	err      error
+	queried  []string
+}
+
+func (r *importUserRepository) ExistingAccounts(_ context.Context, accounts []string) (map[string]bool, error) {
+	r.queried = append(r.queried, accounts...)
+	return r.existing, r.err
+}
+
+func init() {
+	logs.Log = zap.NewNop()
+	logs.SLog = logs.Log.Sugar()
+}
+
+func errorMessages(result UserImportResult) []string {
+	var messages []string
+	for _, err := range result.Errors {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+func TestValidateUserImport(t *testing.T) {
+	repo := &importUserRepository{existing: map[string]bool{"mevcut": true}}
+	service := &UserService{repo: repo}
+
+	rows := []UserImportRow{
+		{Line: 2, Name: "Ayşe Yılmaz", Account: "ayse", Password: "gizli123", Type: "YÖNETİCİ", Status: "hayır"},
+		{Line: 3, Name: "Ayşe Kaya", Account: "ayse", Password: "gizli123"},
+		{Line: 4, Name: "Mehmet", Account: "mevcut", Password: "gizli123", Type: "kullanıcı", Status: "EVET"},
+		{Line: 5, Name: "Uzun", Account: "uzun72", Password: strings.Repeat("ş", 36)},
+		{Line: 6, Name: "Uzun", Account: "uzun73", Password: strings.Repeat("ş", 37)},
+		{Line: 7, Name: "", Account: "", Password: "123", Type: "root", Status: "belki"},
+	}
+	results, err := service.validateUserImport(context.Background(), rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		nil,
+		{"Hesap adı dosyada 2. satırda da var"},
+		{"Bu hesap adı zaten kullanılıyor"},
+		nil,
+		{"Şifre en fazla 72 bayt olabilir"},
+		{
+			"Ad Soyad boş olamaz",
+			"Hesap adı boş olamaz",
+			"Şifre en az 6 karakter olmalıdır",
+			`Geçersiz kullanıcı tipi: "root" (dashboard veya panel olmalı)`,
+			`Geçersiz durum: "belki" (aktif veya pasif olmalı)`,
+		},
+	}
+	for i, result := range results {
+		if got := errorMessages(result); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("satır %d hataları = %q, want %q", result.Row.Line, got, want[i])
+		}
+	}
+
+	if first := results[0].User; first.Type != models.Dashboard || first.Status {
+		t.Errorf("YÖNETİCİ/hayır = %s/%v, dashboard/false bekleniyordu", first.Type, first.Status)
+	}
+	if third := results[2].User; third.Type != models.Panel || !third.Status {
+		t.Errorf("kullanıcı/EVET = %s/%v, panel/true bekleniyordu", third.Type, third.Status)
+	}
+	if !errors.Is(results[1].Errors[0], customerrors.ErrImportAccountDuplicate) {
+		t.Errorf("yinelenen hesap hatası ErrImportAccountDuplicate değil: %v", results[1].Errors[0])
+	}
+	if want := []string{"ayse", "mevcut", "uzun72", "uzun73"}; !reflect.DeepEqual(repo.queried, want) {
+		t.Errorf("sorgulanan hesaplar = %q, want %q", repo.queried, want)
+	}
+
+	report := newUserImportReport(results)
+	if report.Valid != 2 || report.Invalid != 4 || report.Created != 0 {
+		t.Errorf("rapor = %d geçerli/%d geçersiz/%d oluşturulan", report.Valid, report.Invalid, report.Created)
+	}
+}
+
+func TestValidateUserImportEmpty(t *testing.T) {
+	service := &UserService{repo: &importUserRepository{}}
+	for _, rows := range [][]UserImportRow{nil, {}} {
+		if _, err := service.validateUserImport(context.Background(), rows); !errors.Is(err, customerrors.ErrUserImportEmpty) {
+			t.Errorf("boş dosya hatası = %v, ErrUserImportEmpty bekleniyordu", err)
+		}
+	}
+}
+
+func TestValidateUserImportRepositoryError(t *testing.T) {
+	service := &UserService{repo: &importUserRepository{err: errors.New("bağlantı koptu")}}
+	rows := []UserImportRow{{Line: 2, Name: "Ali", Account: "ali", Password: "gizli123"}}
+	if _, err := service.validateUserImport(context.Background(), rows); !errors.Is(err, customerrors.ErrUserImportFailed) {
+		t.Errorf("hata = %v, ErrUserImportFailed bekleniyordu", err)
+	}
+}
+
+func TestParseImportUserType(t *testing.T) {
+	tests := []struct {
+		value string
+		want  models.UserType
+		ok    bool
+	}{
+		{"", models.Panel, true},
+		{"  panel ", models.Panel, true},
+		{"Kullanıcı", models.Panel, true},
+		{"KULLANICI", models.Panel, true},
+		{"user", models.Panel, true},
+		{"dashboard", models.Dashboard, true},
+		{"YÖNETİCİ", models.Dashboard, true},
+		{"yönetici", models.Dashboard, true},
+		{"yonetici", models.Dashboard, true},
+		{"Administrator", models.Dashboard, true},
+		{"admin", models.Panel, false},
+		{"root", models.Panel, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseImportUserType(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseImportUserType(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseImportStatus(t *testing.T) {
+	tests := []struct {
+		value    string
+		want, ok bool
+	}{
+		{"", true, true},
+		{"1", true, true},
+		{"TRUE", true, true},
+		{"Aktif", true, true},
+		{"EVET", true, true},
+		{"active", true, true},
+		{"yes", true, true},
+		{"0", false, true},
+		{"false", false, true},
+		{"PASİF", false, true},
+		{"hayır", false, true},
+		{"HAYIR", false, true},
+		{"hayir", false, true},
+		{"inactive", false, true},
+		{"no", false, true},
+		{"belki", true, false},
+		{"2", true, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseImportStatus(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseImportStatus(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -3,10 +3,12 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 	"zatrano/models"
 	"zatrano/pkg/constants"
 	"zatrano/pkg/customerrors"
 	"zatrano/pkg/logs"
+	"zatrano/pkg/metrics"
 	"zatrano/pkg/queryparams"
 	"zatrano/repositories"
 
@@ -16,23 +18,91 @@ import (
 const contextUserIDKey = "user_id"
 
 type IUserService interface {
-	GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
-	GetUserByID(id uint) (*models.User, error)
+	GetAllUsers(ctx context.Context, params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	UpdateUser(ctx context.Context, id uint, userData *models.User) error
 	DeleteUser(ctx context.Context, id uint) error
-	GetUserCount() (int64, error)
+	GetUserCount(ctx context.Context) (int64, error)
+	ListTrashedUsers(ctx context.Context, params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	RestoreUser(ctx context.Context, id uint) error
+	ForceDeleteUser(ctx context.Context, id uint) error
+	PurgeTrashedUsers(ctx context.Context, before time.Time) (int64, error)
+	GetUserHistory(ctx context.Context, id uint) ([]UserVersion, error)
+	RevertUserToVersion(ctx context.Context, id uint, versionID uint, expectedVersion uint) error
+	BulkUserAction(ctx context.Context, action UserBulkAction, ids []uint, userType models.UserType) (repositories.BulkResult, error)
+	StreamUsers(ctx context.Context, params queryparams.ListParams, trashed bool, fn func(user *models.User) error) error
+	PreviewUserImport(ctx context.Context, rows []UserImportRow) (*UserImportReport, error)
+	ImportUsers(ctx context.Context, rows []UserImportRow) (*UserImportReport, error)
+}
+
+// UserBulkAction kullanıcı listesinde seçili satırlara uygulanabilen toplu işlemdir.
+type UserBulkAction string
+
+const (
+	UserBulkActivate   UserBulkAction = "activate"
+	UserBulkDeactivate UserBulkAction = "deactivate"
+	UserBulkChangeType UserBulkAction = "change_type"
+	UserBulkDelete     UserBulkAction = "delete"
+	UserBulkRestore    UserBulkAction = "restore"
+)
+
+// UserVersion kullanıcı geçmişindeki bir sürümü, bir önceki sürüme göre farkı
+// ve değişikliği yapanın hesap adıyla birlikte taşır.
+type UserVersion struct {
+	models.RecordVersion
+	Changes   models.AuditChanges
+	ActorName string
 }
 
 type UserService struct {
 	repo repositories.IUserRepository
+	tx   repositories.ITxManager
+}
+
+func NewUserService(repo repositories.IUserRepository, tx repositories.ITxManager) IUserService {
+	return &UserService{repo: repo, tx: tx}
 }
 
-func NewUserService() IUserService {
-	return &UserService{repo: repositories.NewUserRepository()}
+func (s *UserService) GetAllUsers(ctx context.Context, params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	users, meta, err := s.repo.List(ctx, normalizeListParams(params))
+	if err != nil {
+		logs.Log.Error("GetAllUsersPaginated: Repository hatası", zap.Error(err))
+		return nil, customerrors.ErrUserListFailed
+	}
+
+	result := &queryparams.PaginatedResult{
+		Data: users,
+		Meta: meta,
+	}
+
+	return result, nil
 }
 
-func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+func (s *UserService) ListTrashedUsers(ctx context.Context, params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	users, meta, err := s.repo.ListTrashed(ctx, normalizeListParams(params))
+	if err != nil {
+		logs.Log.Error("ListTrashedUsers: Repository hatası", zap.Error(err))
+		return nil, customerrors.ErrUserTrashListFailed
+	}
+	return &queryparams.PaginatedResult{Data: users, Meta: meta}, nil
+}
+
+// StreamUsers listeyle aynı filtre ve sıralamadaki tüm kullanıcıları
+// sayfalamadan fn'e verir; dışa aktarma için kullanılır.
+func (s *UserService) StreamUsers(ctx context.Context, params queryparams.ListParams, trashed bool, fn func(user *models.User) error) error {
+	stream := s.repo.Stream
+	if trashed {
+		stream = s.repo.StreamTrashed
+	}
+	if err := stream(ctx, params, fn); err != nil {
+		logs.Log.Error("StreamUsers: Kullanıcılar okunurken hata", zap.Bool("trashed", trashed), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func normalizeListParams(params queryparams.ListParams) queryparams.ListParams {
 	if params.Page <= 0 {
 		params.Page = constants.DefaultPage
 	}
@@ -46,43 +116,18 @@ func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.P
 		)
 		params.PerPage = constants.DefaultPerPage
 	}
-	if params.SortBy == "" {
-		params.SortBy = constants.DefaultSortBy
-	}
-	if params.OrderBy == "" {
-		params.OrderBy = constants.DefaultOrderBy
-	}
-
-	users, totalCount, err := s.repo.GetAllUsers(params)
-	if err != nil {
-		logs.Log.Error("GetAllUsersPaginated: Repository hatası", zap.Error(err))
-		return nil, errors.New("kullanıcılar getirilirken bir hata oluştu")
-	}
-
-	totalPages := queryparams.CalculateTotalPages(totalCount, params.PerPage)
-
-	result := &queryparams.PaginatedResult{
-		Data: users,
-		Meta: queryparams.PaginationMeta{
-			CurrentPage: params.Page,
-			PerPage:     params.PerPage,
-			TotalItems:  totalCount,
-			TotalPages:  totalPages,
-		},
-	}
-
-	return result, nil
+	return params
 }
 
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
-	user, err := s.repo.GetUserByID(id)
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
 			logs.Log.Warn("Kullanıcı bulunamadı (ID ile arama)", zap.Uint("user_id", id))
 			return nil, customerrors.ErrUserServiceUserNotFound
 		}
 		logs.Log.Error("Kullanıcı alınırken hata oluştu (ID ile arama)", zap.Uint("user_id", id), zap.Error(err))
-		return nil, errors.New("kullanıcı bilgileri alınırken bir veritabanı hatası oluştu")
+		return nil, customerrors.ErrUserFetchFailed
 	}
 	return user, nil
 }
@@ -102,7 +147,8 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 		zap.Any("type", user.Type),
 	)
 
-	err := s.repo.CreateUser(ctx, user)
+	err := s.repo.Create(ctx, user)
+	metrics.RecordUserOperation(metrics.UserOperationCreate, err)
 	if err != nil {
 		logs.Log.Error("Kullanıcı oluşturulurken repository hatası",
 			zap.String("account", user.Account),
@@ -115,6 +161,9 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
 	return nil
 }
 
+// UpdateUser güncellemeyi yalnızca kullanıcı hâlâ userData.Version
+// sürümündeyken yapar; arada başka biri değiştirdiyse ErrUserVersionConflict,
+// sürüm verilmemişse ErrUserVersionRequired döner.
 func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.User) error {
 	userIDValue := ctx.Value(contextUserIDKey)
 	currentUserID, ok := userIDValue.(uint)
@@ -122,15 +171,19 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 		logs.Log.Error("UpdateUser: Context'te geçerli user_id bulunamadı veya 0.", zap.Any("value", userIDValue))
 		return customerrors.ErrContextUserIDNotFound
 	}
+	if userData.Version == 0 {
+		logs.Log.Warn("Kullanıcı güncellenemedi: Sürüm bilgisi eksik", zap.Uint("user_id", id))
+		return customerrors.ErrUserVersionRequired
+	}
 
-	_, err := s.repo.GetUserByID(id)
+	_, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
 			logs.Log.Warn("Kullanıcı güncellenemedi: Kullanıcı bulunamadı (ön kontrol)", zap.Uint("user_id", id))
 			return customerrors.ErrUserServiceUserNotFound
 		}
 		logs.Log.Error("Kullanıcı güncellenemedi: Kullanıcı aranırken hata (ön kontrol)", zap.Uint("user_id", id), zap.Error(err))
-		return errors.New("kullanıcı güncellenirken bir veritabanı hatası oluştu (ön kontrol)")
+		return customerrors.ErrUserUpdatePrecheckFailed
 	}
 
 	updateData := map[string]interface{}{
@@ -158,8 +211,16 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 		zap.Uint("updated_by_user_id", currentUserID),
 	)
 
-	err = s.repo.UpdateUser(ctx, id, updateData, currentUserID)
+	err = s.repo.UpdateUserFields(ctx, id, userData.Version, updateData, currentUserID)
+	metrics.RecordUserOperation(metrics.UserOperationUpdate, err)
 	if err != nil {
+		if errors.Is(err, customerrors.ErrVersionConflict) {
+			logs.Log.Warn("Kullanıcı güncellenemedi: Kayıt başka biri tarafından değiştirilmiş",
+				zap.Uint("user_id", id),
+				zap.Uint("expected_version", userData.Version),
+			)
+			return customerrors.ErrUserVersionConflict
+		}
 		logs.Log.Error("Kullanıcı güncellenirken repository hatası",
 			zap.Uint("user_id", id),
 			zap.Error(err),
@@ -177,7 +238,10 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.
 func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
 	logs.Log.Info("Kullanıcı siliniyor...", zap.Uint("user_id", id))
 
-	err := s.repo.DeleteUser(ctx, id)
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
+	metrics.RecordUserOperation(metrics.UserOperationDelete, err)
 	if err != nil {
 		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
 			logs.Log.Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı", zap.Uint("user_id", id))
@@ -190,11 +254,261 @@ func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (s *UserService) GetUserCount() (int64, error) {
-	count, err := s.repo.GetUserCount()
+// RestoreUser çöp kutusundaki kullanıcıyı geri getirir. Aynı hesap adıyla
+// sonradan açılmış aktif bir kullanıcı varsa ErrUserRestoreConflict döner.
+func (s *UserService) RestoreUser(ctx context.Context, id uint) error {
+	logs.Log.Info("Kullanıcı geri yükleniyor...", zap.Uint("user_id", id))
+
+	err := s.repo.Restore(ctx, id)
+	metrics.RecordUserOperation(metrics.UserOperationRestore, err)
+	if err != nil {
+		switch {
+		case errors.Is(err, customerrors.ErrRepoRecordNotFound):
+			logs.Log.Warn("Kullanıcı geri yüklenemedi: Silinmiş kullanıcı bulunamadı", zap.Uint("user_id", id))
+			return customerrors.ErrUserServiceUserNotFound
+		case errors.Is(err, customerrors.ErrRestoreConflict):
+			logs.Log.Warn("Kullanıcı geri yüklenemedi: Hesap adı başka bir kullanıcıda", zap.Uint("user_id", id))
+			return customerrors.ErrUserRestoreConflict
+		}
+		logs.Log.Error("Kullanıcı geri yüklenirken repository hatası", zap.Uint("user_id", id), zap.Error(err))
+		return customerrors.ErrUserRestoreFailed
+	}
+	logs.SLog.Infof("Kullanıcı başarıyla geri yüklendi: ID %d", id)
+	return nil
+}
+
+func (s *UserService) ForceDeleteUser(ctx context.Context, id uint) error {
+	logs.Log.Info("Kullanıcı kalıcı olarak siliniyor...", zap.Uint("user_id", id))
+
+	err := s.repo.ForceDelete(ctx, id)
+	metrics.RecordUserOperation(metrics.UserOperationPurge, err)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
+			logs.Log.Warn("Kullanıcı kalıcı silinemedi: Silinmiş kullanıcı bulunamadı", zap.Uint("user_id", id))
+			return customerrors.ErrUserServiceUserNotFound
+		}
+		logs.Log.Error("Kullanıcı kalıcı silinirken repository hatası", zap.Uint("user_id", id), zap.Error(err))
+		return customerrors.ErrUserPurgeFailed
+	}
+	logs.SLog.Infof("Kullanıcı kalıcı olarak silindi: ID %d", id)
+	return nil
+}
+
+// PurgeTrashedUsers before'dan önce silinmiş kullanıcıları kalıcı olarak siler.
+func (s *UserService) PurgeTrashedUsers(ctx context.Context, before time.Time) (int64, error) {
+	purged, err := s.repo.PurgeTrashed(ctx, before)
+	if err != nil {
+		logs.Log.Error("Silinen kullanıcılar temizlenirken hata oluştu", zap.Time("before", before), zap.Error(err))
+		return 0, customerrors.ErrUserPurgeFailed
+	}
+	if purged > 0 {
+		logs.Log.Info("Saklama süresi dolan kullanıcılar kalıcı olarak silindi",
+			zap.Int64("count", purged),
+			zap.Time("before", before),
+		)
+	}
+	return purged, nil
+}
+
+// BulkUserAction action'ı ids'teki kullanıcılara tek bir transaction içinde
+// uygular. Bir satırın başarısız olması diğerlerini etkilemez; satır hataları
+// kullanıcıya gösterilebilecek hatalara çevrilerek Failed içinde döner.
+// İşlemi yapan kullanıcı kendi hesabını pasifleştiremez veya silemez.
+func (s *UserService) BulkUserAction(ctx context.Context, action UserBulkAction, ids []uint, userType models.UserType) (repositories.BulkResult, error) {
+	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
+	if !ok || currentUserID == 0 {
+		logs.Log.Error("BulkUserAction: Context'te geçerli user_id bulunamadı veya 0.", zap.Any("value", ctx.Value(contextUserIDKey)))
+		return repositories.BulkResult{}, customerrors.ErrContextUserIDNotFound
+	}
+	if len(ids) == 0 {
+		return repositories.BulkResult{}, customerrors.ErrUserBulkEmpty
+	}
+
+	var (
+		operation string
+		run       func(ctx context.Context, ids []uint) (repositories.BulkResult, error)
+	)
+	switch action {
+	case UserBulkActivate, UserBulkDeactivate:
+		operation = metrics.UserOperationUpdate
+		data := map[string]interface{}{"status": action == UserBulkActivate}
+		run = func(ctx context.Context, ids []uint) (repositories.BulkResult, error) {
+			return s.repo.BulkUpdate(ctx, ids, data)
+		}
+	case UserBulkChangeType:
+		if userType != models.Dashboard && userType != models.Panel {
+			return repositories.BulkResult{}, customerrors.ErrUserBulkInvalidType
+		}
+		operation = metrics.UserOperationUpdate
+		data := map[string]interface{}{"type": userType}
+		run = func(ctx context.Context, ids []uint) (repositories.BulkResult, error) {
+			return s.repo.BulkUpdate(ctx, ids, data)
+		}
+	case UserBulkDelete:
+		operation = metrics.UserOperationDelete
+		run = s.repo.BulkDelete
+	case UserBulkRestore:
+		operation = metrics.UserOperationRestore
+		run = s.repo.BulkRestore
+	default:
+		return repositories.BulkResult{}, customerrors.ErrUserBulkInvalidAction
+	}
+
+	selfFailed := false
+	if action == UserBulkDeactivate || action == UserBulkDelete {
+		filtered := make([]uint, 0, len(ids))
+		for _, id := range ids {
+			if id == currentUserID {
+				selfFailed = true
+				continue
+			}
+			filtered = append(filtered, id)
+		}
+		ids = filtered
+	}
+
+	logs.Log.Info("Kullanıcılara toplu işlem uygulanıyor...",
+		zap.String("action", string(action)),
+		zap.Uints("user_ids", ids),
+		zap.Uint("actor_user_id", currentUserID),
+	)
+
+	var result repositories.BulkResult
+	err := s.tx.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = run(ctx, ids)
+		return err
+	})
+	if err != nil {
+		logs.Log.Error("Kullanıcı toplu işlemi başarısız oldu",
+			zap.String("action", string(action)),
+			zap.Error(err),
+		)
+		return repositories.BulkResult{}, customerrors.ErrUserBulkFailed
+	}
+
+	for range result.Succeeded {
+		metrics.RecordUserOperation(operation, nil)
+	}
+	for id, rowErr := range result.Failed {
+		metrics.RecordUserOperation(operation, rowErr)
+		result.Failed[id] = bulkRowError(action, id, rowErr)
+	}
+	if selfFailed {
+		result.Failed[currentUserID] = customerrors.ErrUserBulkSelf
+	}
+
+	logs.Log.Info("Kullanıcı toplu işlemi tamamlandı",
+		zap.String("action", string(action)),
+		zap.Int("succeeded", len(result.Succeeded)),
+		zap.Int("failed", len(result.Failed)),
+	)
+	return result, nil
+}
+
+// bulkRowError bir satırın repository hatasını tekil işlemlerdeki karşılığına çevirir.
+func bulkRowError(action UserBulkAction, id uint, err error) error {
+	switch {
+	case errors.Is(err, customerrors.ErrRepoRecordNotFound):
+		return customerrors.ErrUserServiceUserNotFound
+	case errors.Is(err, customerrors.ErrRestoreConflict):
+		return customerrors.ErrUserRestoreConflict
+	}
+	logs.Log.Error("Kullanıcı toplu işleminde satır hatası",
+		zap.String("action", string(action)),
+		zap.Uint("user_id", id),
+		zap.Error(err),
+	)
+	switch action {
+	case UserBulkDelete:
+		return customerrors.ErrUserDeletionFailed
+	case UserBulkRestore:
+		return customerrors.ErrUserRestoreFailed
+	}
+	return customerrors.ErrUserUpdateFailed
+}
+
+func (s *UserService) GetUserHistory(ctx context.Context, id uint) ([]UserVersion, error) {
+	versions, err := s.repo.ListVersions(ctx, id)
+	if err != nil {
+		logs.Log.Error("Kullanıcı sürüm geçmişi alınırken hata oluştu", zap.Uint("user_id", id), zap.Error(err))
+		return nil, customerrors.ErrUserHistoryFailed
+	}
+
+	actorNames := make(map[uint]string)
+	history := make([]UserVersion, len(versions))
+	for i, version := range versions {
+		var previous *models.RecordVersion
+		if i+1 < len(versions) {
+			previous = &versions[i+1]
+		}
+		history[i] = UserVersion{RecordVersion: version, Changes: version.Diff(previous)}
+
+		actorID := version.Actor()
+		if actorID == 0 {
+			continue
+		}
+		if _, ok := actorNames[actorID]; !ok {
+			actorNames[actorID] = ""
+			if actor, err := s.repo.GetByID(ctx, actorID); err == nil {
+				actorNames[actorID] = actor.Account
+			}
+		}
+		history[i].ActorName = actorNames[actorID]
+	}
+	return history, nil
+}
+
+// RevertUserToVersion kullanıcıyı geçmişteki bir sürümün değerlerine döndürür.
+// Geri alma normal UpdateUser yolundan geçer; böylece hook'lar, audit ve yeni
+// bir sürüm kaydı oluşur. Şifre sürümlerde saklanmadığından değişmez.
+func (s *UserService) RevertUserToVersion(ctx context.Context, id uint, versionID uint, expectedVersion uint) error {
+	version, err := s.repo.GetVersion(ctx, id, versionID)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrRepoRecordNotFound) {
+			logs.Log.Warn("Kullanıcı sürümü bulunamadı", zap.Uint("user_id", id), zap.Uint("version_id", versionID))
+			return customerrors.ErrUserVersionNotFound
+		}
+		logs.Log.Error("Kullanıcı sürümü alınırken hata oluştu", zap.Uint("user_id", id), zap.Uint("version_id", versionID), zap.Error(err))
+		return customerrors.ErrUserVersionFetchFailed
+	}
+
+	userData, err := userFromSnapshot(version.Snapshot)
+	if err != nil {
+		logs.Log.Error("Kullanıcı sürümü geri yüklenemedi: Anlık görüntü geçersiz", zap.Uint("version_id", versionID), zap.Error(err))
+		return err
+	}
+	userData.Version = expectedVersion
+
+	logs.Log.Info("Kullanıcı önceki bir sürüme döndürülüyor...",
+		zap.Uint("user_id", id),
+		zap.Uint("version_id", versionID),
+		zap.Uint("target_version", version.Version),
+	)
+	return s.UpdateUser(ctx, id, userData)
+}
+
+func userFromSnapshot(snapshot models.Snapshot) (*models.User, error) {
+	name, okName := snapshot["name"].(string)
+	account, okAccount := snapshot["account"].(string)
+	userType, okType := snapshot["type"].(string)
+	status, okStatus := snapshot["status"].(bool)
+	if !okName || !okAccount || !okType || !okStatus {
+		return nil, customerrors.ErrUserVersionInvalid
+	}
+	return &models.User{
+		Name:    name,
+		Account: account,
+		Status:  status,
+		Type:    models.UserType(userType),
+	}, nil
+}
+
+func (s *UserService) GetUserCount(ctx context.Context) (int64, error) {
+	count, err := s.repo.GetCount(ctx)
 	if err != nil {
 		logs.Log.Error("Kullanıcı sayısı alınırken hata oluştu", zap.Error(err))
-		return 0, errors.New("kullanıcı sayısı alınırken bir hata oluştu")
+		return 0, customerrors.ErrUserCountFailed
 	}
 	return count, nil
 }